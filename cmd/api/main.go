@@ -1,22 +1,88 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
 	"github.com/PR-Developers/server-health-monitor/internal/api/server"
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
 	"github.com/PR-Developers/server-health-monitor/internal/database"
 	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
 )
 
 func main() {
+	// The config has to be loaded before anything reads a variable, including the logger
+	printConfig, err := utils.LoadConfig(os.Args[0], os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if printConfig {
+		utils.PrintConfig(os.Stdout)
+		return
+	}
+
+	os.Exit(run())
+}
+
+// run serves the api until it fails or receives SIGINT/SIGTERM and returns the exit code
+func run() int {
 	log := logger.Instance()
 	log.Info("Server Health Monitor API")
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	server := server.New()
 
 	database, err := database.Instance()
 	if err != nil {
 		panic(err)
 	}
-	defer database.Disconnect()
+	// Disconnect once the server has stopped, requests being drained still use the database
+	defer func() {
+		if err := database.Disconnect(); err != nil {
+			log.Errorf("Failed to disconnect from the database (%s)", err.Error())
+		}
+	}()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Start()
+	}()
+
+	select {
+	case err := <-errs:
+		if err != nil {
+			log.Errorf("API stopped (%s)", err.Error())
+			return 1
+		}
+		return 0
+	case <-ctx.Done():
+	}
 
-	server.Start()
+	timeout, err := strconv.Atoi(utils.GetVariable(consts.SHUTDOWN_TIMEOUT))
+	if err != nil {
+		timeout = 30
+	}
+	log.Infof("Shutting down, waiting up to %d seconds for requests to finish", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Errorf("Failed to shut down gracefully (%s)", err.Error())
+		return 1
+	}
+	log.Info("API stopped")
+	return 0
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/host"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+// sample is a line written by the collect command
+type sample struct {
+	Host   *types.Host  `json:"host"`
+	Health types.Health `json:"health"`
+}
+
+// collect writes the host information and a health sample to stdout as JSON without contacting
+// the api, once or every interval until it is asked to stop
+func collect(once bool) {
+	// Log messages would corrupt the JSON written to stdout
+	logger.SetConsole(os.Stderr)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	settings := newSettings()
+	encoder := json.NewEncoder(os.Stdout)
+	if once {
+		encoder.SetIndent("", "  ")
+	}
+	// Utilization and rates are calculated between two samples, the first one only serves as the baseline
+	collectHealth(enabledCollectors(settings))
+	delay := time.Second
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		encoder.Encode(sample{Host: host.GetInfo(), Health: collectHealth(enabledCollectors(settings))})
+		if once {
+			return
+		}
+		delay = settings.Interval()
+	}
+}
@@ -2,51 +2,383 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/PR-Developers/server-health-monitor/internal/client"
 	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/batch"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/buffer"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/certificate"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/check"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/config"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/cpu"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/disk"
 	"github.com/PR-Developers/server-health-monitor/internal/data-collector/host"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/load"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/logwatch"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/memory"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/network"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/probe"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/process"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/remote"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/sensor"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/status"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/store"
 	"github.com/PR-Developers/server-health-monitor/internal/logger"
 	"github.com/PR-Developers/server-health-monitor/internal/types"
 	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+const (
+	// bufferReplayInterval is the delay between replaying buffered health data in the background
+	bufferReplayInterval = time.Second * 30
 )
 
 func main() {
+	// The first argument selects the command unless it is a flag, running the agent is the default
+	command, args := "run", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	flags := flag.NewFlagSet(os.Args[0]+" "+command, flag.ContinueOnError)
+	var once, dryRun bool
+	switch command {
+	case "run":
+		flags.BoolVar(&dryRun, "dry-run", false, "logs the payloads instead of sending them to the api")
+	case "collect":
+		flags.BoolVar(&once, "once", false, "prints a single sample and exits")
+	case "check-connectivity":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s (expected run, collect or check-connectivity)\n", command)
+		os.Exit(2)
+	}
+
+	// The config has to be loaded before anything reads a variable, including the logger
+	printConfig, err := utils.LoadConfigFlags(flags, args)
+	if err == nil && flags.NArg() > 0 {
+		err = fmt.Errorf("unexpected argument: %s", flags.Arg(0))
+	}
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if printConfig {
+		utils.PrintConfig(os.Stdout)
+		return
+	}
+
+	switch command {
+	case "collect":
+		collect(once)
+	case "check-connectivity":
+		if !client.CheckConnectivity(utils.GetVariable(consts.API_URL), os.Stdout) {
+			os.Exit(1)
+		}
+	default:
+		run(dryRun)
+	}
+}
+
+// run collects and sends data to the api until the agent is asked to stop. A dry run logs the
+// payloads instead of sending them
+func run(dryRun bool) {
 	log := logger.Instance()
 	log.Info("Server Health Monitor - Data Collector Tool")
 
-	// TODO: pass arguments to GetVariable
-	client, err := client.NewClient(utils.GetVariable(consts.API_URL))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := newClient(dryRun)
 	if err != nil {
 		panic(err)
 	}
+	// Every other request is rejected until the agent is enrolled, unless it presents a client certificate
+	enrollmentToken := utils.GetVariable(consts.ENROLLMENT_TOKEN)
+	if enrollmentToken != "" || utils.GetVariable(consts.AGENT_CERT) == "" {
+		if err := client.Enroll(enrollmentToken); err != nil {
+			log.Errorf("Failed to enroll with the api (%s)", err.Error())
+			os.Exit(1)
+		}
+	}
 
-	delay, err := strconv.Atoi(utils.GetVariable(consts.MINUTES_SINCE_HEALTH_SHOW_OFFLINE))
-	if err != nil {
-		delay = 30
+	// Every request to the api is recorded for the local status endpoints
+	recorder := status.NewRecorder(store.Instance(&wrapper.DefaultOS{}).GetAgentInformation().ID.String())
+	client = status.WrapClient(client, recorder)
+	var stopStatus func(ctx context.Context) error
+	if address := utils.GetVariable(consts.STATUS_ADDRESS); address != "" {
+		if stopStatus, err = status.Serve(address, recorder); err != nil {
+			log.Errorf("Failed to serve the local status endpoints on %s (%s)", address, err.Error())
+		} else {
+			log.Infof("Serving /metrics and /status on %s", address)
+		}
 	}
 
-	payload := new(bytes.Buffer)
-	json.NewEncoder(payload).Encode(host.GetInfo())
+	settings := newSettings()
+
+	sendHost := func(info *types.Host) bool {
+		payload := new(bytes.Buffer)
+		json.NewEncoder(payload).Encode(info)
+		_, statusCode, _ := client.Post("host/", payload)
+		log.Infof("Sent host data and got a status code of %v", statusCode)
+		return statusCode == http.StatusOK
+	}
 	log.Info("Sending initial host data")
-	_, statusCode, _ := client.Post("host/", payload)
-	log.Infof("Sent host data and got a status code of %v", statusCode)
+	var sentHost *types.Host
+	if info := host.GetInfo(); sendHost(info) {
+		sentHost = info
+	}
+	inventoryInterval, err := strconv.Atoi(utils.GetVariable(consts.HOST_INVENTORY_INTERVAL))
+	if err != nil {
+		inventoryInterval = 3600
+	}
+	// The inventory is only sent again when it changed, such as after a kernel upgrade or a reboot
+	stopInventory := host.Watch(sentHost, time.Second*time.Duration(inventoryInterval), sendHost)
 
-	for {
+	agentConfig := config.Get()
+	sendCheck := func(result types.CheckResult) {
+		payload := new(bytes.Buffer)
+		json.NewEncoder(payload).Encode(result)
+		_, statusCode, _ := client.Post("checks/", payload)
+		log.Infof("Sent %s check result and got a status code of %v", result.Name, statusCode)
+	}
+	sendProbe := func(result types.ProbeResult) {
+		payload := new(bytes.Buffer)
+		json.NewEncoder(payload).Encode(result)
+		_, statusCode, _ := client.Post("probes/", payload)
+		log.Infof("Sent %s probe result and got a status code of %v", result.Name, statusCode)
+	}
+	stopChecks := check.Start(agentConfig.Checks, sendCheck)
+	stopProbes := probe.Start(agentConfig.Probes, sendProbe)
+
+	remoteInterval, err := strconv.Atoi(utils.GetVariable(consts.REMOTE_CONFIG_INTERVAL))
+	if err != nil {
+		remoteInterval = 300
+	}
+	// Checks and probes are restarted with the merged definitions whenever the remote config changes,
+	// the lock keeps a late change from starting them again once they were stopped
+	var definitionsLock sync.Mutex
+	definitionsStopped := false
+	stopDefinitions := func() {
+		definitionsLock.Lock()
+		defer definitionsLock.Unlock()
+		definitionsStopped = true
+		stopChecks()
+		stopProbes()
+	}
+	stopRemote := remote.Watch(client, utils.GetVariable(consts.AGENT_GROUP), time.Second*time.Duration(remoteInterval), func(remoteConfig types.RemoteConfig) {
+		definitionsLock.Lock()
+		defer definitionsLock.Unlock()
+		if definitionsStopped {
+			return
+		}
+		merged := config.Merge(agentConfig, remoteConfig)
+		stopChecks()
+		stopProbes()
+		stopChecks = check.Start(merged.Checks, sendCheck)
+		stopProbes = probe.Start(merged.Probes, sendProbe)
+		settings.Apply(remoteConfig)
+	})
+	stopCertificates := certificate.Start(agentConfig.Certificates, func(certificates []types.Certificate) {
+		payload := new(bytes.Buffer)
+		json.NewEncoder(payload).Encode(certificates)
+		_, statusCode, _ := client.Post("certificates/", payload)
+		log.Infof("Sent %d certificates and got a status code of %v", len(certificates), statusCode)
+	})
+	stopLogs := logwatch.Start(agentConfig.Logs, func(event types.Event) {
+		payload := new(bytes.Buffer)
+		json.NewEncoder(payload).Encode(event)
+		_, statusCode, _ := client.Post("events/", payload)
+		log.Infof("Sent %s event for %s and got a status code of %v", event.Rule, event.Source, statusCode)
+	})
+
+	maxEntries, err := strconv.Atoi(utils.GetVariable(consts.BUFFER_MAX_ENTRIES))
+	if err != nil {
+		maxEntries = 1000
+	}
+	maxAge, err := strconv.Atoi(utils.GetVariable(consts.BUFFER_MAX_AGE))
+	if err != nil {
+		maxAge = 1440
+	}
+	// A dry run leaves the buffer alone, it would otherwise be emptied without sending anything
+	bufferFile := utils.GetVariable(consts.BUFFER_FILE)
+	if dryRun {
+		bufferFile = os.DevNull
+	}
+	healthBuffer := buffer.New(
+		store.NewFileStore(&wrapper.DefaultOS{}, bufferFile),
+		maxEntries, time.Minute*time.Duration(maxAge),
+	)
+	batchSize, err := strconv.Atoi(utils.GetVariable(consts.HEALTH_BATCH_SIZE))
+	if err != nil {
+		batchSize = 10
+	}
+	batchMaxAge, err := strconv.Atoi(utils.GetVariable(consts.HEALTH_BATCH_MAX_AGE))
+	if err != nil {
+		batchMaxAge = 60
+	}
+	recorder.TrackQueue(healthBuffer.Len)
+	healthBatch := batch.New(batchSize, time.Second*time.Duration(batchMaxAge))
+	sendHealth := func(url string, data []byte) bool {
+		_, statusCode, err := client.Post(url, bytes.NewReader(data))
+		if err != nil {
+			log.Errorf("Failed to send health data (%s)", err.Error())
+			return false
+		}
+		log.Infof("Sent health data and got a status code of %v", statusCode)
+		// The api rejecting the data will not change by sending it again
+		return statusCode < 500 && statusCode != 429
+	}
+	submitHealth := func(samples []types.Health) {
+		data, _ := json.Marshal(samples)
+		// New health data waits behind older buffered data so it reaches the api in the order it was sampled
+		if healthBuffer.Len() > 0 || !sendHealth("health/batch", data) {
+			healthBuffer.Add("health/batch", data)
+			log.Infof("Buffered health data, %d packets are waiting to be sent", healthBuffer.Len())
+		}
+	}
+	// The buffer is replayed in the background so an unreachable api does not delay collecting health data
+	stopReplay := scheduler.Every(bufferReplayInterval, func() {
+		healthBuffer.Replay(sendHealth)
+	})
+
+	for ctx.Err() == nil {
 		// Collect new data
 
 		// Make request
-		log.Info("Sending new health data")
-		health := types.Health{
-			Uptime: host.GetInfo().Uptime,
+		log.Info("Collecting new health data")
+		collectors := enabledCollectors(settings)
+		health := collectHealth(collectors)
+		recorder.RecordHealth(health, collectors)
+		if samples := healthBatch.Add(health); samples != nil {
+			submitHealth(samples)
+		}
+
+		if settings.IsEnabled(consts.COLLECTOR_PROCESS, process.IsEnabled()) {
+			if snapshot := process.GetSnapshot(); snapshot != nil {
+				payload := new(bytes.Buffer)
+				json.NewEncoder(payload).Encode(snapshot)
+				_, statusCode, _ := client.Post("processes/", payload)
+				log.Infof("Sent process snapshot and got a status code of %v", statusCode)
+			}
+		}
+		// Delay for the interval set locally or by the remote config, unless asked to stop
+		select {
+		case <-ctx.Done():
+		case <-time.After(settings.Interval()):
+		}
+	}
+
+	timeout, err := strconv.Atoi(utils.GetVariable(consts.SHUTDOWN_TIMEOUT))
+	if err != nil {
+		timeout = 30
+	}
+	log.Infof("Shutting down, waiting up to %d seconds for pending health data to be sent", timeout)
+	// An unreachable api must not keep the agent from stopping
+	time.AfterFunc(time.Second*time.Duration(timeout), func() {
+		log.Error("Timed out while sending pending health data")
+		os.Exit(1)
+	})
+
+	stopRemote()
+	stopInventory()
+	stopDefinitions()
+	stopCertificates()
+	stopLogs()
+	stopReplay()
+	if samples := healthBatch.Flush(); samples != nil {
+		data, _ := json.Marshal(samples)
+		// The samples are buffered first so they are kept on disk if sending them times out
+		healthBuffer.Add("health/batch", data)
+	}
+	if !healthBuffer.Replay(sendHealth) {
+		log.Infof("Stopping with %d buffered packets which will be sent on the next start", healthBuffer.Len())
+	}
+	if stopStatus != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		stopStatus(stopCtx)
+		cancel()
+	}
+	log.Info("Data collector stopped")
+}
+
+// newClient returns the client for the api, or one which only logs the requests during a dry run
+func newClient(dryRun bool) (client.Client, error) {
+	if dryRun {
+		logger.Instance().Info("Dry run, payloads are logged instead of being sent to the api")
+		return client.NewDryRunClient(), nil
+	}
+	return client.NewClient(utils.GetVariable(consts.API_URL))
+}
+
+// newSettings returns the collector settings with the locally configured health interval
+func newSettings() *config.Settings {
+	delay, err := strconv.Atoi(utils.GetVariable(consts.DC_HEALTH_DELAY))
+	if err != nil {
+		delay = 30
+	}
+	return config.NewSettings(time.Second * time.Duration(delay))
+}
+
+// enabledCollectors returns the name of every health collector which is enabled
+func enabledCollectors(settings *config.Settings) []string {
+	collectors := []string{}
+	for _, collector := range []string{
+		consts.COLLECTOR_CPU,
+		consts.COLLECTOR_MEMORY,
+		consts.COLLECTOR_DISK,
+		consts.COLLECTOR_DISK_IO,
+		consts.COLLECTOR_NETWORK,
+		consts.COLLECTOR_LOAD,
+		consts.COLLECTOR_SENSOR,
+	} {
+		if settings.IsEnabled(collector, true) {
+			collectors = append(collectors, collector)
+		}
+	}
+	return collectors
+}
+
+// collectHealth returns a new health sample with the data of the given collectors
+func collectHealth(collectors []string) types.Health {
+	health := types.Health{
+		CreateTime: time.Now().UTC().UnixNano(),
+		Uptime:     host.GetInfo().Uptime,
+	}
+	for _, collector := range collectors {
+		switch collector {
+		case consts.COLLECTOR_CPU:
+			health.CPU = cpu.GetUtilization()
+		case consts.COLLECTOR_MEMORY:
+			health.Memory = memory.GetInfo()
+		case consts.COLLECTOR_DISK:
+			health.Disks = disk.GetUsage()
+		case consts.COLLECTOR_DISK_IO:
+			health.DiskIO = disk.GetIORates()
+		case consts.COLLECTOR_NETWORK:
+			health.Network = network.GetRates()
+		case consts.COLLECTOR_LOAD:
+			health.Load = load.GetInfo()
+		case consts.COLLECTOR_SENSOR:
+			health.Temperatures = sensor.GetTemperatures()
+			health.Fans = sensor.GetFans()
 		}
-		json.NewEncoder(payload).Encode(health)
-		_, statusCode, _ := client.Post("health/", payload)
-		log.Infof("Sent health data and got a status code of %v", statusCode)
-		// Delay for X seconds
-		time.Sleep(time.Second * time.Duration(delay))
 	}
+	return health
 }
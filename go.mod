@@ -4,9 +4,11 @@ go 1.16
 
 require (
 	github.com/google/uuid v1.3.0
+	github.com/klauspost/compress v1.9.5
 	github.com/labstack/echo/v4 v4.4.0
 	github.com/shirou/gopsutil/v3 v3.21.6
 	github.com/stretchr/testify v1.7.0
 	go.mongodb.org/mongo-driver v1.6.0
 	golang.org/x/net v0.0.0-20210805182204-aaa1db679c0d
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
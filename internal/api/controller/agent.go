@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// AgentController provides an agent enrollment service to interact with
+type AgentController struct {
+	service  service.IAgentService
+	adminKey string
+}
+
+// NewAgentController returns a new AgentController with the service/repository initialized
+func NewAgentController() *AgentController {
+	return &AgentController{
+		service:  service.NewAgentService(repository.NewEnrollmentTokenRepository(), repository.NewAgentRepository()),
+		adminKey: utils.GetVariable(consts.ADMIN_API_KEY),
+	}
+}
+
+// Enroll exchanges the enrollment token of the requesting agent for its secret
+func (controller *AgentController) Enroll(c echo.Context) error {
+	request := new(types.EnrollmentRequest)
+
+	if err := c.Bind(request); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind enrollment data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	if certificate := clientCertificate(c); certificate != nil && !utils.CertificateMatchesAgent(certificate, c.Request().Header.Get("Agent-ID")) {
+		return c.JSON(http.StatusUnauthorized, types.StandardResponse{
+			StatusCode: http.StatusUnauthorized,
+			Error:      "client certificate does not identify the agent",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.Enroll(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"),
+		request.Token,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostEnrollmentToken creates a token agents can enroll with
+func (controller *AgentController) PostEnrollmentToken(c echo.Context) error {
+	token := new(types.EnrollmentToken)
+
+	if err := c.Bind(token); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind enrollment token data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.CreateEnrollmentToken(c.Response().Header().Get("X-Request-ID"), token)
+	return c.JSON(res.StatusCode, res)
+}
+
+// DeleteAgent revokes an enrolled agent
+func (controller *AgentController) DeleteAgent(c echo.Context) error {
+	res := controller.service.RevokeAgent(c.Response().Header().Get("X-Request-ID"), c.Param("agent-id"))
+	return c.JSON(res.StatusCode, res)
+}
+
+// RequireAgent is a middleware which only lets enrolled agents which send their secret through. Agents
+// which present a verified client certificate are authenticated by it instead
+func (controller *AgentController) RequireAgent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := c.Response().Header().Get("X-Request-ID")
+		agentID := c.Request().Header.Get("Agent-ID")
+
+		var res types.AgentResponse
+		if certificate := clientCertificate(c); certificate != nil {
+			res = controller.service.AuthenticateCertificate(requestID, agentID, certificate)
+		} else {
+			res = controller.service.Authenticate(requestID, agentID, c.Request().Header.Get("Agent-Secret"))
+		}
+		if !res.Success {
+			return c.JSON(res.StatusCode, res)
+		}
+		return next(c)
+	}
+}
+
+// RequireAdmin is a middleware which only lets requests with the admin key as bearer token through.
+// Without an admin key every request is rejected
+func (controller *AgentController) RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if controller.adminKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(controller.adminKey)) != 1 {
+			return c.JSON(http.StatusUnauthorized, types.StandardResponse{
+				StatusCode: http.StatusUnauthorized,
+				Error:      "a valid admin key is required",
+				Success:    false,
+				Data:       nil,
+			})
+		}
+		return next(c)
+	}
+}
+
+// clientCertificate returns the client certificate of a request if it was verified against the client CA
+func clientCertificate(c echo.Context) *x509.Certificate {
+	state := c.Request().TLS
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
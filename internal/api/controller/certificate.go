@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// defaultCertificateLimit is the number of certificates returned when no limit is given
+	defaultCertificateLimit = 10
+)
+
+// CertificateController provides a certificate service to interact with
+type CertificateController struct {
+	service service.ICertificateService
+}
+
+// NewCertificateController returns a new CertificateController with the service/repository initialized
+func NewCertificateController() *CertificateController {
+	return &CertificateController{
+		service: service.NewCertificateService(repository.NewCertificateRepository(), &wrapper.DefaultOS{}),
+	}
+}
+
+// GetExpiringCertificates returns the soonest expiring certificates across all agents
+func (controller *CertificateController) GetExpiringCertificates(c echo.Context) error {
+	limit := int64(defaultCertificateLimit)
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return c.JSON(400, types.StandardResponse{
+				StatusCode: 400,
+				Error:      "failed to parse limit",
+				Success:    false,
+				Data:       nil,
+			})
+		}
+		limit = parsed
+	}
+
+	res := controller.service.GetExpiringCertificates(
+		c.Response().Header().Get("X-Request-ID"),
+		limit,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// GetCertificatesByAgentId returns the certificates reported by an agent
+func (controller *CertificateController) GetCertificatesByAgentId(c echo.Context) error {
+	res := controller.service.GetCertificatesByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostCertificates replaces the certificates reported by an agent
+func (controller *CertificateController) PostCertificates(c echo.Context) error {
+	certificates := []types.Certificate{}
+
+	if err := c.Bind(&certificates); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind certificate data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddCertificates(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), certificates,
+	)
+	return c.JSON(res.StatusCode, res)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// CheckController provides a check result service to interact with
+type CheckController struct {
+	service service.ICheckService
+}
+
+// NewCheckController returns a new CheckController with the service/repository initialized
+func NewCheckController() *CheckController {
+	return &CheckController{
+		service: service.NewCheckService(repository.NewCheckRepository()),
+	}
+}
+
+// GetChecksByAgentId returns the check results for an agent, optionally between the from and to timestamps
+func (controller *CheckController) GetChecksByAgentId(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetChecksByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostCheck adds a check result for an agent
+func (controller *CheckController) PostCheck(c echo.Context) error {
+	result := new(types.CheckResult)
+
+	if err := c.Bind(result); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind check data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddCheck(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), result,
+	)
+	return c.JSON(res.StatusCode, res)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// EventController provides a log event service to interact with
+type EventController struct {
+	service service.IEventService
+}
+
+// NewEventController returns a new EventController with the service/repository initialized
+func NewEventController() *EventController {
+	return &EventController{
+		service: service.NewEventService(repository.NewEventRepository()),
+	}
+}
+
+// GetEventsByAgentId returns the log events for an agent, optionally between the from and to timestamps
+func (controller *EventController) GetEventsByAgentId(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetEventsByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostEvent adds a log event for an agent
+func (controller *EventController) PostEvent(c echo.Context) error {
+	event := new(types.Event)
+
+	if err := c.Bind(event); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind event data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddEvent(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), event,
+	)
+	return c.JSON(res.StatusCode, res)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -14,6 +15,11 @@ import (
 	"golang.org/x/net/websocket"
 )
 
+const (
+	// maxHealthBatchSize is the largest amount of health samples accepted in a single batch
+	maxHealthBatchSize = 1000
+)
+
 // HealthController provides a health service to interact with
 type HealthController struct {
 	service     service.IHealthService
@@ -22,10 +28,12 @@ type HealthController struct {
 
 // NewHealthController returns a new HealthController with the service/repository initialized
 func NewHealthController() *HealthController {
-	healthService := service.NewHealthService(repository.NewHealthRepository(), repository.NewHostRepository())
+	healthRepository := repository.NewHealthRepository()
+	rebootService := service.NewRebootService(repository.NewRebootRepository(), healthRepository)
+	healthService := service.NewHealthService(healthRepository, repository.NewHostRepository(), rebootService)
 	return &HealthController{
 		service:     healthService,
-		hostService: service.NewHostService(repository.NewHostRepository(), healthService),
+		hostService: service.NewHostService(repository.NewHostRepository(), repository.NewHostHistoryRepository(), healthService, rebootService),
 	}
 }
 
@@ -92,7 +100,13 @@ func (controller *HealthController) GetHealthWS(c echo.Context) error {
 	if err != nil {
 		delay = 30
 	}
-	time.Sleep(time.Second * time.Duration(delay))
+	// The request context is cancelled when the api shuts down
+	ctx := c.Request().Context()
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Second * time.Duration(delay)):
+	}
 
 	log := logger.Instance()
 	requestID := c.Response().Header().Get("X-Request-ID")
@@ -101,14 +115,22 @@ func (controller *HealthController) GetHealthWS(c echo.Context) error {
 	websocket.Handler(func(ws *websocket.Conn) {
 		defer ws.Close()
 		for {
+			// Taken before the query so data received while it runs is part of the next one
+			checkTime := time.Now().UTC().UnixNano()
 			res := controller.service.GetLatestHealthDataForAgents(requestID, lastCheck)
-			websocket.JSON.Send(ws, res)
+			if err := websocket.JSON.Send(ws, res); err != nil {
+				return
+			}
 			if !res.Success {
 				log.Error(res.Error)
 			}
 
-			lastCheck = time.Now().UTC().UnixNano()
-			time.Sleep(time.Second * time.Duration(delay))
+			lastCheck = checkTime
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second * time.Duration(delay)):
+			}
 		}
 	}).ServeHTTP(c.Response(), c.Request())
 	return nil
@@ -141,3 +163,31 @@ func (controller *HealthController) PostHealth(c echo.Context) error {
 	)
 	return c.JSON(res.StatusCode, res)
 }
+
+// PostHealthBatch adds multiple health samples for an agent at once
+func (controller *HealthController) PostHealthBatch(c echo.Context) error {
+	health := []types.Health{}
+
+	if err := c.Bind(&health); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind health data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+	if len(health) > maxHealthBatchSize {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      fmt.Sprintf("a batch can contain at most %d health samples", maxHealthBatchSize),
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddHealthBatch(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), health,
+	)
+	return c.JSON(res.StatusCode, res)
+}
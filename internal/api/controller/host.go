@@ -15,8 +15,12 @@ type HostController struct {
 // NewHostController returns a new HostController with the service/repository initialized
 func NewHostController() *HostController {
 	hostRepository := repository.NewHostRepository()
+	healthRepository := repository.NewHealthRepository()
+	rebootService := service.NewRebootService(repository.NewRebootRepository(), healthRepository)
 	return &HostController{
-		service: service.NewHostService(hostRepository, service.NewHealthService(repository.NewHealthRepository(), hostRepository)),
+		service: service.NewHostService(hostRepository, repository.NewHostHistoryRepository(),
+			service.NewHealthService(healthRepository, hostRepository, rebootService), rebootService,
+		),
 	}
 }
 
@@ -58,3 +62,23 @@ func (controller *HostController) PostHost(c echo.Context) error {
 	)
 	return c.JSON(res.StatusCode, res)
 }
+
+// GetHostHistoryById returns the inventory changes of a host, optionally between the from and to timestamps
+func (controller *HostController) GetHostHistoryById(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetHostHistoryByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// ProbeController provides a probe result service to interact with
+type ProbeController struct {
+	service service.IProbeService
+}
+
+// NewProbeController returns a new ProbeController with the service/repository initialized
+func NewProbeController() *ProbeController {
+	return &ProbeController{
+		service: service.NewProbeService(repository.NewProbeRepository()),
+	}
+}
+
+// GetProbesByAgentId returns the probe results for an agent, optionally between the from and to timestamps
+func (controller *ProbeController) GetProbesByAgentId(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetProbesByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostProbe adds a probe result for an agent
+func (controller *ProbeController) PostProbe(c echo.Context) error {
+	result := new(types.ProbeResult)
+
+	if err := c.Bind(result); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind probe data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddProbe(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), result,
+	)
+	return c.JSON(res.StatusCode, res)
+}
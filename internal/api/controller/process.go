@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// ProcessController provides a process snapshot service to interact with
+type ProcessController struct {
+	service service.IProcessService
+}
+
+// NewProcessController returns a new ProcessController with the service/repository initialized
+func NewProcessController() *ProcessController {
+	return &ProcessController{
+		service: service.NewProcessService(repository.NewProcessRepository()),
+	}
+}
+
+// GetProcessesByAgentId returns the process snapshots for an agent, optionally between the from and to timestamps
+func (controller *ProcessController) GetProcessesByAgentId(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetProcessesByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PostProcesses adds a process snapshot for an agent
+func (controller *ProcessController) PostProcesses(c echo.Context) error {
+	snapshot := new(types.ProcessSnapshot)
+
+	if err := c.Bind(snapshot); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind process data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.AddProcesses(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"), snapshot,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// parseTimeRange reads the optional from and to query parameters (unix nanoseconds)
+func parseTimeRange(c echo.Context) (int64, int64, error) {
+	var from, to int64
+	var err error
+	if value := c.QueryParam("from"); value != "" {
+		if from, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	if value := c.QueryParam("to"); value != "" {
+		if to, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	return from, to, nil
+}
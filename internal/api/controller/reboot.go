@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// RebootController provides a host reboot service to interact with
+type RebootController struct {
+	service service.IRebootService
+}
+
+// NewRebootController returns a new RebootController with the service/repository initialized
+func NewRebootController() *RebootController {
+	return &RebootController{
+		service: service.NewRebootService(repository.NewRebootRepository(), repository.NewHealthRepository()),
+	}
+}
+
+// GetReboots returns the reboots of every host, optionally between the from and to timestamps
+func (controller *RebootController) GetReboots(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetReboots(
+		c.Response().Header().Get("X-Request-ID"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// GetRebootsByAgentId returns the reboots of a host, optionally between the from and to timestamps
+func (controller *RebootController) GetRebootsByAgentId(c echo.Context) error {
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to parse from/to timestamps",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.GetRebootsByAgentID(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("agent-id"),
+		from, to,
+	)
+	return c.JSON(res.StatusCode, res)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/service"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/labstack/echo/v4"
+)
+
+// RemoteConfigController provides a remote agent configuration service to interact with
+type RemoteConfigController struct {
+	service service.IRemoteConfigService
+}
+
+// NewRemoteConfigController returns a new RemoteConfigController with the service/repository initialized
+func NewRemoteConfigController() *RemoteConfigController {
+	return &RemoteConfigController{
+		service: service.NewRemoteConfigService(repository.NewRemoteConfigRepository()),
+	}
+}
+
+// GetEffectiveRemoteConfig returns the configuration of the requesting agent, including the configuration of its group
+func (controller *RemoteConfigController) GetEffectiveRemoteConfig(c echo.Context) error {
+	res := controller.service.GetEffectiveRemoteConfig(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Request().Header.Get("Agent-ID"),
+		c.QueryParam("group"),
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// GetRemoteConfig returns the stored configuration of an agent or a group
+func (controller *RemoteConfigController) GetRemoteConfig(c echo.Context) error {
+	res := controller.service.GetRemoteConfig(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("scope"), c.Param("name"),
+	)
+	return c.JSON(res.StatusCode, res)
+}
+
+// PutRemoteConfig replaces the configuration of an agent or a group
+func (controller *RemoteConfigController) PutRemoteConfig(c echo.Context) error {
+	config := new(types.RemoteConfig)
+
+	if err := c.Bind(config); err != nil {
+		return c.JSON(400, types.StandardResponse{
+			StatusCode: 400,
+			Error:      "failed to bind remote config data",
+			Success:    false,
+			Data:       nil,
+		})
+	}
+
+	res := controller.service.SetRemoteConfig(
+		c.Response().Header().Get("X-Request-ID"),
+		c.Param("scope"), c.Param("name"), config,
+	)
+	return c.JSON(res.StatusCode, res)
+}
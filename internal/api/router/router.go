@@ -11,21 +11,59 @@ func Setup(e *echo.Echo) {
 	// General setup
 	health := controller.NewHealthController()
 	host := controller.NewHostController()
+	process := controller.NewProcessController()
+	check := controller.NewCheckController()
+	probe := controller.NewProbeController()
+	certificate := controller.NewCertificateController()
+	event := controller.NewEventController()
+	remoteConfig := controller.NewRemoteConfigController()
+	agent := controller.NewAgentController()
+	reboot := controller.NewRebootController()
 
 	// Public routes
+	e.POST("/api/v1/agents/enroll", func(c echo.Context) error { return agent.Enroll(c) })
+
+	// Admin routes
+	e.POST("/api/v1/agents/tokens", func(c echo.Context) error { return agent.PostEnrollmentToken(c) }, agent.RequireAdmin)
+	e.DELETE("/api/v1/agents/:agent-id", func(c echo.Context) error { return agent.DeleteAgent(c) }, agent.RequireAdmin)
+	// Remote configuration defines the commands agents run as checks
+	e.GET("/api/v1/config/:scope/:name", func(c echo.Context) error { return remoteConfig.GetRemoteConfig(c) }, agent.RequireAdmin)
+	e.PUT("/api/v1/config/:scope/:name", func(c echo.Context) error { return remoteConfig.PutRemoteConfig(c) }, agent.RequireAdmin)
+
+	// Agent routes, which require an enrolled agent
+	e.POST("/api/v1/health/", func(c echo.Context) error { return health.PostHealth(c) }, agent.RequireAgent)
+	e.POST("/api/v1/health/batch", func(c echo.Context) error { return health.PostHealthBatch(c) }, agent.RequireAgent)
+	e.POST("/api/v1/host/", func(c echo.Context) error { return host.PostHost(c) }, agent.RequireAgent)
+	e.POST("/api/v1/processes/", func(c echo.Context) error { return process.PostProcesses(c) }, agent.RequireAgent)
+	e.POST("/api/v1/events/", func(c echo.Context) error { return event.PostEvent(c) }, agent.RequireAgent)
+	e.POST("/api/v1/checks/", func(c echo.Context) error { return check.PostCheck(c) }, agent.RequireAgent)
+	e.POST("/api/v1/probes/", func(c echo.Context) error { return probe.PostProbe(c) }, agent.RequireAgent)
+	e.POST("/api/v1/certificates/", func(c echo.Context) error { return certificate.PostCertificates(c) }, agent.RequireAgent)
+	e.GET("/api/v1/config/", func(c echo.Context) error { return remoteConfig.GetEffectiveRemoteConfig(c) }, agent.RequireAgent)
 
 	// TODO: setup auth middleware
 
 	// Private routes
 	e.GET("/api/v1/health/", func(c echo.Context) error { return health.GetHealth(c) })
 	e.GET("/api/v1/health/:agent-id", func(c echo.Context) error { return health.GetHealthByAgentId(c) })
-	e.POST("/api/v1/health/", func(c echo.Context) error { return health.PostHealth(c) })
 	e.POST("/api/v1/health/:agent-id/:since", func(c echo.Context) error { return health.GetLatestHealthDataForAgentByAgentId(c) })
 	e.POST("/api/v1/health/:since", func(c echo.Context) error { return health.GetLatestHealthDataForAgents(c) })
 
 	e.GET("/api/v1/host/", func(c echo.Context) error { return host.GetHosts(c) })
 	e.GET("/api/v1/host/:agent-id", func(c echo.Context) error { return host.GetHostById(c) })
-	e.POST("/api/v1/host/", func(c echo.Context) error { return host.PostHost(c) })
+	e.GET("/api/v1/host/:agent-id/history", func(c echo.Context) error { return host.GetHostHistoryById(c) })
+	e.GET("/api/v1/host/:agent-id/processes", func(c echo.Context) error { return process.GetProcessesByAgentId(c) })
+	e.GET("/api/v1/host/:agent-id/events", func(c echo.Context) error { return event.GetEventsByAgentId(c) })
+	e.GET("/api/v1/host/:agent-id/reboots", func(c echo.Context) error { return reboot.GetRebootsByAgentId(c) })
+
+	e.GET("/api/v1/reboots/", func(c echo.Context) error { return reboot.GetReboots(c) })
+
+	e.GET("/api/v1/checks/:agent-id", func(c echo.Context) error { return check.GetChecksByAgentId(c) })
+
+	e.GET("/api/v1/probes/:agent-id", func(c echo.Context) error { return probe.GetProbesByAgentId(c) })
+
+	e.GET("/api/v1/certificates/", func(c echo.Context) error { return certificate.GetExpiringCertificates(c) })
+	e.GET("/api/v1/certificates/:agent-id", func(c echo.Context) error { return certificate.GetCertificatesByAgentId(c) })
 
 	// Websockets
 	e.GET("/ws/v1/health/", func(c echo.Context) error { return health.GetHealthWS(c) })
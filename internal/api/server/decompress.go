@@ -0,0 +1,70 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/klauspost/compress/zstd"
+	"github.com/labstack/echo/v4"
+)
+
+// decompress returns a middleware which transparently decompresses gzip and zstd request bodies.
+// Bodies which are larger than limit once decompressed are rejected to guard against zip bombs
+func decompress(limit int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			encoding := strings.ToLower(strings.TrimSpace(request.Header.Get(echo.HeaderContentEncoding)))
+			if encoding == "" || encoding == "identity" {
+				return next(c)
+			}
+
+			var reader io.ReadCloser
+			switch encoding {
+			case "gzip":
+				gzipReader, err := gzip.NewReader(request.Body)
+				if err != nil {
+					return rejectBody(c, http.StatusBadRequest, "failed to decompress gzip body")
+				}
+				reader = gzipReader
+			case "zstd":
+				zstdReader, err := zstd.NewReader(request.Body, zstd.WithDecoderMaxMemory(uint64(limit)))
+				if err != nil {
+					return rejectBody(c, http.StatusBadRequest, "failed to decompress zstd body")
+				}
+				reader = zstdReader.IOReadCloser()
+			default:
+				return rejectBody(c, http.StatusUnsupportedMediaType, "unsupported content encoding: "+encoding)
+			}
+			defer reader.Close()
+
+			data, err := ioutil.ReadAll(io.LimitReader(reader, limit+1))
+			if err != nil {
+				return rejectBody(c, http.StatusBadRequest, "failed to decompress "+encoding+" body")
+			}
+			if int64(len(data)) > limit {
+				return rejectBody(c, http.StatusRequestEntityTooLarge, "decompressed body is too large")
+			}
+
+			request.Body = ioutil.NopCloser(bytes.NewReader(data))
+			request.ContentLength = int64(len(data))
+			request.Header.Del(echo.HeaderContentEncoding)
+			return next(c)
+		}
+	}
+}
+
+// rejectBody responds with an error when a request body can not be used
+func rejectBody(c echo.Context, statusCode int, message string) error {
+	return c.JSON(statusCode, types.StandardResponse{
+		StatusCode: statusCode,
+		Error:      message,
+		Success:    false,
+		Data:       nil,
+	})
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipBody(t *testing.T, data string) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(data))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func zstdBody(t *testing.T, data string) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	assert.Nil(t, err)
+	defer encoder.Close()
+	return encoder.EncodeAll([]byte(data), nil)
+}
+
+func serveDecompressed(body []byte, encoding string, limit int64) (*httptest.ResponseRecorder, string) {
+	received := ""
+	handler := decompress(limit)(func(c echo.Context) error {
+		data, _ := ioutil.ReadAll(c.Request().Body)
+		received = string(data)
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/health/", bytes.NewReader(body))
+	if encoding != "" {
+		request.Header.Set(echo.HeaderContentEncoding, encoding)
+	}
+	recorder := httptest.NewRecorder()
+	handler(echo.New().NewContext(request, recorder))
+	return recorder, received
+}
+
+func TestDecompress_PassesUncompressedBodies(t *testing.T) {
+	recorder, received := serveDecompressed([]byte(`{"uptime":1}`), "", 1024)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"uptime":1}`, received)
+}
+
+func TestDecompress_DecompressesGzipBodies(t *testing.T) {
+	recorder, received := serveDecompressed(gzipBody(t, `{"uptime":1}`), "gzip", 1024)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"uptime":1}`, received)
+}
+
+func TestDecompress_DecompressesZstdBodies(t *testing.T) {
+	recorder, received := serveDecompressed(zstdBody(t, `{"uptime":1}`), "zstd", 1024)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"uptime":1}`, received)
+}
+
+func TestDecompress_RejectsBodiesOverTheLimit(t *testing.T) {
+	recorder, received := serveDecompressed(gzipBody(t, strings.Repeat("a", 1025)), "gzip", 1024)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Equal(t, "", received)
+}
+
+func TestDecompress_RejectsInvalidBodies(t *testing.T) {
+	recorder, _ := serveDecompressed([]byte("not gzip"), "gzip", 1024)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestDecompress_RejectsUnsupportedEncodings(t *testing.T) {
+	recorder, _ := serveDecompressed([]byte("data"), "br", 1024)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+}
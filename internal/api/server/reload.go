@@ -0,0 +1,124 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+)
+
+// certificateReloader serves the certificate of the api and replaces it when its files change or the
+// process receives SIGHUP, so renewed certificates are used without dropping open connections
+type certificateReloader struct {
+	certFile    string
+	keyFile     string
+	certificate atomic.Value // *tls.Certificate
+	modTimes    [2]time.Time
+	lock        sync.Mutex
+	log         logger.Logger
+}
+
+// newCertificateReloader returns a reloader which has loaded the certificate and key files
+func newCertificateReloader(certFile string, keyFile string) (*certificateReloader, error) {
+	reloader := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		log:      logger.Instance(),
+	}
+	reloader.changed()
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// GetCertificate returns the current certificate, it is used as the GetCertificate callback of the TLS config
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.certificate.Load().(*tls.Certificate), nil
+}
+
+// reload loads the certificate and key files. The current certificate is kept if they are invalid,
+// ie, while only one of them has been replaced
+func (r *certificateReloader) reload() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	if certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+		return err
+	}
+
+	r.certificate.Store(&certificate)
+	r.log.Infof("loaded api certificate %s which expires on %s",
+		certificate.Leaf.Subject.String(), certificate.Leaf.NotAfter.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// changed returns if the certificate or key file was modified since the last time this was called
+func (r *certificateReloader) changed() bool {
+	changed := false
+	for i, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[i]) {
+			r.modTimes[i] = info.ModTime()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// watch reloads the certificate on SIGHUP and, unless interval is 0, when the files have changed
+// since they were checked an interval ago. Calling the returned function stops watching
+func (r *certificateReloader) watch(interval time.Duration) func() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	var ticks <-chan time.Time
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		ticks = ticker.C
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer signal.Stop(hangup)
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+		for {
+			select {
+			case <-done:
+				return
+			case <-hangup:
+				r.log.Info("received SIGHUP, reloading the api certificate")
+			case <-ticks:
+				if !r.changed() {
+					continue
+				}
+			}
+			if err := r.reload(); err != nil {
+				r.log.Errorf("failed to reload the api certificate, keeping the current one (%s)", err.Error())
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"crypto/tls"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// replaceCertificate writes a new certificate and key, moving their modification time forward so the
+// change is seen on file systems with a coarse timestamp resolution
+func replaceCertificate(t *testing.T, dir string, certificate *testCertificate, modTime time.Time) {
+	for _, file := range []string{
+		writeFile(t, dir, "api.crt", certificate.certPEM),
+		writeFile(t, dir, "api.key", certificate.keyPEM),
+	} {
+		assert.Nil(t, os.Chtimes(file, modTime, modTime))
+	}
+}
+
+func servedCommonName(t *testing.T, reloader *certificateReloader) string {
+	certificate, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Nil(t, err)
+	return certificate.Leaf.Subject.CommonName
+}
+
+func TestServer_CertificateReloader_ReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca := createCertificate(t, "ca", nil)
+	replaceCertificate(t, dir, createCertificate(t, "api-1", ca), time.Now().Add(-time.Hour))
+
+	reloader, err := newCertificateReloader(dir+"/api.crt", dir+"/api.key")
+	assert.Nil(t, err)
+	assert.Equal(t, "api-1", servedCommonName(t, reloader))
+	assert.False(t, reloader.changed())
+
+	stop := reloader.watch(time.Millisecond * 10)
+	defer stop()
+	replaceCertificate(t, dir, createCertificate(t, "api-2", ca), time.Now())
+
+	assert.Eventually(t, func() bool {
+		return servedCommonName(t, reloader) == "api-2"
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestServer_CertificateReloader_KeepsCertificateWhenInvalid(t *testing.T) {
+	dir := t.TempDir()
+	ca := createCertificate(t, "ca", nil)
+	replaceCertificate(t, dir, createCertificate(t, "api-1", ca), time.Now().Add(-time.Hour))
+
+	reloader, err := newCertificateReloader(dir+"/api.crt", dir+"/api.key")
+	assert.Nil(t, err)
+
+	// Only the certificate has been replaced so far, it does not match the key
+	writeFile(t, dir, "api.crt", createCertificate(t, "api-2", ca).certPEM)
+
+	assert.True(t, reloader.changed())
+	assert.NotNil(t, reloader.reload())
+	assert.Equal(t, "api-1", servedCommonName(t, reloader))
+
+	_, err = newCertificateReloader(dir+"/api.crt", dir+"/api.key")
+	assert.NotNil(t, err)
+}
@@ -1,7 +1,14 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/PR-Developers/server-health-monitor/internal/api/router"
 	"github.com/PR-Developers/server-health-monitor/internal/consts"
@@ -14,11 +21,15 @@ import (
 
 // Server is an interface which provides method signatures for an HTTP server
 type Server interface {
-	Start()
+	Start() error
+	Shutdown(ctx context.Context) error
 }
 
 type echoServer struct {
 	Instance *echo.Echo
+	tracker  *requestTracker
+	context  context.Context
+	cancel   context.CancelFunc
 }
 
 var (
@@ -27,13 +38,17 @@ var (
 
 // New returns a new instance of an echo HTTP server
 func New() Server {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &echoServer{
 		Instance: echo.New(),
+		tracker:  &requestTracker{},
+		context:  ctx,
+		cancel:   cancel,
 	}
 }
 
-// Start the web server
-func (s *echoServer) Start() {
+// Start the web server. It blocks until the server fails or has been shut down, which returns nil
+func (s *echoServer) Start() error {
 	e := s.Instance
 
 	// Currently this server is only used for the core API so the logic below
@@ -41,19 +56,74 @@ func (s *echoServer) Start() {
 	// the below can be done via first-class functions
 	e.Pre(middleware.HTTPSRedirect())
 
+	e.Use(s.tracker.middleware)
 	e.Use(middleware.RequestID())
 	e.Use(middleware.Recover())
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Output: logger.Instance().Logger().Writer(),
 	}))
 
+	maxDecompressedSize, err := strconv.ParseInt(utils.GetVariable(consts.MAX_DECOMPRESSED_SIZE), 10, 64)
+	if err != nil {
+		maxDecompressedSize = 10 * 1024 * 1024
+	}
+	e.Use(decompress(maxDecompressedSize))
+	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		// Websocket connections are hijacked and can not be compressed
+		Skipper: func(c echo.Context) bool {
+			return !strings.HasPrefix(c.Request().URL.Path, "/api/")
+		},
+	}))
+
 	router.Setup(e)
 
 	port := utils.GetVariable(consts.API_PORT)
 	port = fmt.Sprintf(":%s", port)
 
 	certDir := utils.GetVariable(consts.CERT_DIR)
-	e.Logger.Fatal(e.StartTLS(port,
+	clientCAFile := ""
+	if clientCA := utils.GetVariable(consts.CLIENT_CA); clientCA != "" {
+		clientCAFile = fmt.Sprintf("%s/%s", certDir, clientCA)
+	}
+	reloader, err := newCertificateReloader(
 		fmt.Sprintf("%s/%s", certDir, utils.GetVariable(consts.API_CERT)),
-		fmt.Sprintf("%s/%s", certDir, utils.GetVariable(consts.API_KEY))))
+		fmt.Sprintf("%s/%s", certDir, utils.GetVariable(consts.API_KEY)),
+	)
+	if err != nil {
+		return err
+	}
+	reloadInterval, err := strconv.Atoi(utils.GetVariable(consts.CERT_RELOAD_INTERVAL))
+	if err != nil {
+		reloadInterval = 60
+	}
+	// Renewed certificates are picked up without a restart, which would drop open websockets
+	stopReload := reloader.watch(time.Second * time.Duration(reloadInterval))
+	defer stopReload()
+
+	tlsConfig, err := newTLSConfig(reloader, clientCAFile)
+	if err != nil {
+		return err
+	}
+	if !e.DisableHTTP2 {
+		tlsConfig.NextProtos = append(tlsConfig.NextProtos, "h2")
+	}
+
+	e.TLSServer.Addr = port
+	e.TLSServer.TLSConfig = tlsConfig
+	// Every request context is cancelled on shutdown so long running handlers, ie, websockets, can stop
+	e.TLSServer.BaseContext = func(net.Listener) context.Context { return s.context }
+	if err = e.StartServer(e.TLSServer); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits until the requests being handled, including
+// websockets, have finished or ctx is done
+func (s *echoServer) Shutdown(ctx context.Context) error {
+	s.cancel()
+	if err := s.Instance.Shutdown(ctx); err != nil {
+		return err
+	}
+	return s.tracker.wait(ctx)
 }
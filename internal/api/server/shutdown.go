@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// requestTracker counts the requests being handled, including hijacked websocket connections which
+// http.Server.Shutdown does not wait for
+type requestTracker struct {
+	requests sync.WaitGroup
+}
+
+// middleware tracks every request until its handler returns
+func (t *requestTracker) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		t.requests.Add(1)
+		defer t.requests.Done()
+		return next(c)
+	}
+}
+
+// wait blocks until every tracked request has been handled or ctx is done
+func (t *requestTracker) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.requests.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_RequestTracker_WaitsForRequests(t *testing.T) {
+	tracker := &requestTracker{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := tracker.middleware(func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	e := echo.New()
+	go handler(e.NewContext(httptest.NewRequest(http.MethodGet, "/ws/v1/health/", nil), httptest.NewRecorder()))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tracker.wait(ctx))
+
+	close(release)
+	assert.Nil(t, tracker.wait(context.Background()))
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// createCertificate creates a certificate signed by parent, or a self signed CA when parent is nil
+func createCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeFile writes data to a file in dir and returns its path
+func writeFile(t *testing.T, dir string, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	assert.Nil(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+// requestWithCertificate makes a request to server, presenting certificate when it is set, and
+// returns the common name of the verified client certificate seen by the server
+func requestWithCertificate(t *testing.T, url string, ca *testCertificate, certificate *testCertificate) (string, error) {
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.certificate)
+	config := &tls.Config{RootCAs: rootCAs}
+	if certificate != nil {
+		pair, err := tls.X509KeyPair(certificate.certPEM, certificate.keyPEM)
+		assert.Nil(t, err)
+		// Always present the certificate, even if the server does not accept its CA
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &pair, nil
+		}
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+	response, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	return string(body), err
+}
+
+func TestServer_NewTLSConfig_VerifiesClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := createCertificate(t, "ca", nil)
+	api := createCertificate(t, "api", ca)
+	agent := createCertificate(t, "agent-1", ca)
+	untrusted := createCertificate(t, "agent-2", createCertificate(t, "other-ca", nil))
+
+	reloader, err := newCertificateReloader(writeFile(t, dir, "api.crt", api.certPEM), writeFile(t, dir, "api.key", api.keyPEM))
+	assert.Nil(t, err)
+	config, err := newTLSConfig(reloader, writeFile(t, dir, "ca.crt", ca.certPEM))
+	assert.Nil(t, err)
+
+	// httptest would serve its own certificate instead of the one of the reloader
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := &http.Server{
+		TLSConfig: config,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(r.TLS.VerifiedChains) > 0 {
+				w.Write([]byte(r.TLS.VerifiedChains[0][0].Subject.CommonName))
+			}
+		}),
+	}
+	go server.ServeTLS(listener, "", "")
+	defer server.Close()
+	url := "https://" + listener.Addr().String()
+
+	commonName, err := requestWithCertificate(t, url, ca, agent)
+	assert.Nil(t, err)
+	assert.Equal(t, "agent-1", commonName)
+
+	commonName, err = requestWithCertificate(t, url, ca, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "", commonName)
+
+	_, err = requestWithCertificate(t, url, ca, untrusted)
+	assert.NotNil(t, err)
+}
+
+func TestServer_NewTLSConfig_ReturnsErrors(t *testing.T) {
+	dir := t.TempDir()
+	api := createCertificate(t, "api", createCertificate(t, "ca", nil))
+	reloader, err := newCertificateReloader(writeFile(t, dir, "api.crt", api.certPEM), writeFile(t, dir, "api.key", api.keyPEM))
+	assert.Nil(t, err)
+
+	config, err := newTLSConfig(reloader, "")
+	assert.Nil(t, err)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	_, err = newTLSConfig(reloader, filepath.Join(dir, "missing.crt"))
+	assert.NotNil(t, err)
+
+	_, err = newTLSConfig(reloader, writeFile(t, dir, "ca.crt", []byte("not a certificate")))
+	assert.Equal(t, "no certificates found in "+filepath.Join(dir, "ca.crt"), err.Error())
+}
@@ -1,15 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/PR-Developers/server-health-monitor/internal/consts"
 	"github.com/PR-Developers/server-health-monitor/internal/data-collector/store"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
 	"github.com/PR-Developers/server-health-monitor/internal/types"
 	"github.com/PR-Developers/server-health-monitor/internal/utils"
 	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
@@ -19,12 +24,17 @@ import (
 type Client interface {
 	Get(url string) ([]byte, int, error)
 	Post(url string, data io.Reader) ([]byte, int, error)
+	Enroll(token string) error
 }
 
 type standardClient struct {
 	baseURL          string
 	httpClient       *http.Client
+	store            store.Store
 	agentInformation types.AgentInformation
+	retryPolicy      RetryPolicy
+	breaker          *circuitBreaker
+	compression      string
 }
 
 var (
@@ -34,50 +44,136 @@ var (
 // NewClient returns an instanced HTTP client
 func NewClient(baseURL string) (Client, error) {
 	store := store.Instance(&wrapper.DefaultOS{})
-	certDir := utils.GetVariable(consts.CERT_DIR)
-	caCert, err := ioutil.ReadFile(certDir + "/" + utils.GetVariable(consts.CLIENT_CERT))
+	tlsConfig, err := newTLSConfig()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
 
 	return &standardClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: time.Second * 30,
 			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					RootCAs: caCertPool,
-				},
+				TLSClientConfig: tlsConfig,
 			},
 		},
+		store:            store,
 		agentInformation: store.GetAgentInformation(),
+		retryPolicy: RetryPolicy{
+			MaxRetries: getIntVariable(consts.CLIENT_MAX_RETRIES, 3),
+			BaseDelay:  time.Millisecond * time.Duration(getIntVariable(consts.CLIENT_RETRY_BASE_DELAY, 500)),
+			MaxDelay:   time.Millisecond * time.Duration(getIntVariable(consts.CLIENT_RETRY_MAX_DELAY, 30000)),
+		},
+		breaker: newCircuitBreaker(
+			getIntVariable(consts.CLIENT_BREAKER_THRESHOLD, 5),
+			time.Second*time.Duration(getIntVariable(consts.CLIENT_BREAKER_COOLDOWN, 60)),
+		),
+		compression: supportedCompression(utils.GetVariable(consts.CLIENT_COMPRESSION)),
 	}, nil
 }
 
-// makeRequest will make any HTTP request and also sends common data required for each request
+// newTLSConfig returns the TLS config which trusts the certificate authority of the api and
+// presents the client certificate of the agent when one is set
+func newTLSConfig() (*tls.Config, error) {
+	certDir := utils.GetVariable(consts.CERT_DIR)
+	caCert, err := ioutil.ReadFile(certDir + "/" + utils.GetVariable(consts.CLIENT_CERT))
+	if err != nil {
+		return nil, err
+	}
+	caCertPool := x509.NewCertPool()
+	caCertPool.AppendCertsFromPEM(caCert)
+	tlsConfig := &tls.Config{
+		RootCAs: caCertPool,
+	}
+	// The agent only presents a client certificate when the api uses mutual TLS
+	if agentCert := utils.GetVariable(consts.AGENT_CERT); agentCert != "" {
+		certificate, err := tls.LoadX509KeyPair(certDir+"/"+agentCert, certDir+"/"+utils.GetVariable(consts.AGENT_KEY))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// getIntVariable returns the integer value of a given key or the fallback if it is not a number
+func getIntVariable(key string, fallback int) int {
+	value, err := strconv.Atoi(utils.GetVariable(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// makeRequest will make any HTTP request, retrying it as long as the retry policy allows, and
+// also sends common data required for each request. No request is made while the circuit breaker is open
 func (c *standardClient) makeRequest(method string, url string, body io.Reader) ([]byte, int, error) {
+	var payload []byte
+	var encoding string
+	if body != nil {
+		var err error
+		if payload, err = ioutil.ReadAll(body); err != nil {
+			return nil, 0, &RequestError{Err: err}
+		}
+		if payload, encoding, err = compress(c.compression, payload); err != nil {
+			return nil, 0, &RequestError{Err: err}
+		}
+	}
+
+	if !c.breaker.allow() {
+		return nil, 0, ErrCircuitOpen
+	}
+
+	for attempt := 0; ; attempt++ {
+		responseBody, statusCode, retryAfter, err := c.attempt(method, url, payload, encoding)
+		retryable := isRetryable(statusCode, err)
+		if !retryable || attempt >= c.retryPolicy.MaxRetries {
+			c.breaker.record(!retryable)
+			return responseBody, statusCode, err
+		}
+
+		delay, ok := c.retryPolicy.delay(attempt, retryAfter)
+		if !ok {
+			c.breaker.record(false)
+			return responseBody, statusCode, err
+		}
+		sleep(delay)
+	}
+}
+
+// attempt makes a single HTTP request and returns the Retry-After of the response
+func (c *standardClient) attempt(method string, url string, payload []byte, encoding string) ([]byte, int, time.Duration, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
 	request, err := http.NewRequest(method, c.baseURL+url, body)
 	if err != nil {
-		return nil, -100, err
+		return nil, 0, 0, &RequestError{Err: err}
 	}
 
 	request.Header.Add("Agent-ID", c.agentInformation.ID.String())
+	if c.agentInformation.Secret != "" {
+		request.Header.Set("Agent-Secret", c.agentInformation.Secret)
+	}
 	request.Header.Set("Content-Type", "application/json")
+	if encoding != "" {
+		request.Header.Set("Content-Encoding", encoding)
+	}
 
 	response, err := c.httpClient.Do(request)
 	if err != nil {
-		return nil, -101, err
+		return nil, 0, 0, &TransportError{Err: err}
 	}
 
 	defer response.Body.Close()
+	retryAfter := parseRetryAfter(response.Header.Get("Retry-After"))
 	responseBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return nil, -102, err
+		return nil, response.StatusCode, retryAfter, &ResponseError{Err: err}
 	}
 
-	return responseBody, response.StatusCode, nil
+	return responseBody, response.StatusCode, retryAfter, nil
 }
 
 // Get makes a GET request to a given URL
@@ -89,3 +185,45 @@ func (c *standardClient) Get(url string) ([]byte, int, error) {
 func (c *standardClient) Post(url string, data io.Reader) ([]byte, int, error) {
 	return c.makeRequest("POST", url, data)
 }
+
+// Enroll exchanges an enrollment token for the secret of this agent and keeps it in the store. An agent
+// which already holds a secret is only enrolled again when a token is set and the api rejects the secret
+// (ie, the agent was revoked). It has to be called before any other request is made
+func (c *standardClient) Enroll(token string) error {
+	if c.agentInformation.Secret != "" {
+		if token == "" || !c.isSecretRejected() {
+			return nil
+		}
+		logger.Instance().Warning("the api rejected the secret of this agent, enrolling again")
+		c.agentInformation.Secret = ""
+	}
+	if token == "" {
+		return fmt.Errorf("the agent is not enrolled and no enrollment token is set")
+	}
+
+	payload, err := json.Marshal(types.EnrollmentRequest{Token: token})
+	if err != nil {
+		return &RequestError{Err: err}
+	}
+	body, statusCode, err := c.Post("agents/enroll", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	var response types.EnrollmentResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return &ResponseError{Err: err}
+	}
+	if statusCode != http.StatusOK || len(response.Data) == 0 {
+		return fmt.Errorf("failed to enroll with status code %d (%s)", statusCode, response.Error)
+	}
+
+	c.agentInformation.Secret = response.Data[0].Secret
+	return c.store.SetAgentInformation(c.agentInformation)
+}
+
+// isSecretRejected returns true if the api does not accept the secret of this agent
+func (c *standardClient) isSecretRejected() bool {
+	_, statusCode, err := c.Get("config/")
+	return err == nil && statusCode == http.StatusUnauthorized
+}
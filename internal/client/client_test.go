@@ -0,0 +1,298 @@
+package client
+
+import (
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/client/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../ -r --name Store
+
+func getInitializedClient(t *testing.T, statusCodes []int, headers map[string]string) (*standardClient, *[]string, *[]time.Duration) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		statusCode := statusCodes[len(statusCodes)-1]
+		if len(bodies) <= len(statusCodes) {
+			statusCode = statusCodes[len(bodies)-1]
+		}
+		w.WriteHeader(statusCode)
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	delays := []time.Duration{}
+	sleep = func(delay time.Duration) { delays = append(delays, delay) }
+	random = func() float64 { return 1 }
+	now = time.Now
+
+	return &standardClient{
+		baseURL:    server.URL + "/",
+		httpClient: server.Client(),
+		retryPolicy: RetryPolicy{
+			MaxRetries: 3,
+			BaseDelay:  time.Millisecond * 100,
+			MaxDelay:   time.Second,
+		},
+		breaker: newCircuitBreaker(2, time.Minute),
+	}, &bodies, &delays
+}
+
+func TestClient_Post_RetriesServerErrorsWithBackoff(t *testing.T) {
+	client, bodies, delays := getInitializedClient(t, []int{500, 503, 200}, nil)
+
+	body, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, []string{"data", "data", "data"}, *bodies)
+	assert.Equal(t, []time.Duration{time.Millisecond * 100, time.Millisecond * 200}, *delays)
+}
+
+func TestClient_Post_DoesNotRetryClientErrors(t *testing.T) {
+	client, bodies, _ := getInitializedClient(t, []int{400}, nil)
+
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 400, statusCode)
+	assert.Equal(t, 1, len(*bodies))
+}
+
+func TestClient_Post_ReturnsLastResponseWhenRetriesExhausted(t *testing.T) {
+	client, bodies, delays := getInitializedClient(t, []int{500}, nil)
+
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 500, statusCode)
+	assert.Equal(t, 4, len(*bodies))
+	assert.Equal(t, []time.Duration{time.Millisecond * 100, time.Millisecond * 200, time.Millisecond * 400}, *delays)
+}
+
+func TestClient_Post_HonorsRetryAfter(t *testing.T) {
+	client, _, delays := getInitializedClient(t, []int{429, 200}, map[string]string{"Retry-After": "1"})
+
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, []time.Duration{time.Second}, *delays)
+}
+
+func TestClient_Post_StopsWhenRetryAfterIsTooLong(t *testing.T) {
+	client, bodies, delays := getInitializedClient(t, []int{429, 200}, map[string]string{"Retry-After": "120"})
+
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 429, statusCode)
+	assert.Equal(t, 1, len(*bodies))
+	assert.Empty(t, *delays)
+}
+
+func TestClient_Post_ReturnsTransportError(t *testing.T) {
+	client, _, delays := getInitializedClient(t, []int{200}, nil)
+	client.baseURL = "http://127.0.0.1:0/"
+
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	var transportError *TransportError
+	assert.True(t, errors.As(err, &transportError))
+	assert.Equal(t, 0, statusCode)
+	assert.Equal(t, 3, len(*delays))
+}
+
+func TestClient_Get_ReturnsRequestError(t *testing.T) {
+	client, _, delays := getInitializedClient(t, []int{200}, nil)
+
+	_, _, err := client.Get("%zz")
+
+	var requestError *RequestError
+	assert.True(t, errors.As(err, &requestError))
+	assert.Empty(t, *delays)
+}
+
+func TestClient_Post_OpensCircuitBreaker(t *testing.T) {
+	client, bodies, _ := getInitializedClient(t, []int{500, 500, 500, 500, 500, 500, 500, 500, 200}, nil)
+	currentTime := time.Unix(1000, 0)
+	now = func() time.Time { return currentTime }
+
+	client.Post("health/", strings.NewReader("data"))
+	client.Post("health/", strings.NewReader("data"))
+	_, _, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, 8, len(*bodies))
+
+	currentTime = currentTime.Add(time.Minute)
+	_, statusCode, err := client.Post("health/", strings.NewReader("data"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.True(t, client.breaker.allow())
+}
+
+func TestClient_ParseRetryAfter_HandlesDates(t *testing.T) {
+	now = func() time.Time { return time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	assert.Equal(t, time.Second*30, parseRetryAfter("Tue, 01 Jan 2030 00:00:30 GMT"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Mon, 31 Dec 2029 00:00:00 GMT"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+}
+
+func TestClient_Post_CompressesLargeBodies(t *testing.T) {
+	var encoding string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		reader, _ := gzip.NewReader(r.Body)
+		received, _ = ioutil.ReadAll(reader)
+	}))
+	defer server.Close()
+	client := &standardClient{
+		baseURL:     server.URL + "/",
+		httpClient:  server.Client(),
+		breaker:     newCircuitBreaker(0, 0),
+		compression: "gzip",
+	}
+	data := strings.Repeat("a", minCompressionSize)
+
+	_, statusCode, err := client.Post("health/", strings.NewReader(data))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, "gzip", encoding)
+	assert.Equal(t, data, string(received))
+}
+
+func TestClient_Compress_HandlesEncodings(t *testing.T) {
+	data := []byte(strings.Repeat("a", minCompressionSize))
+
+	payload, encoding, err := compress("zstd", data)
+	assert.Nil(t, err)
+	assert.Equal(t, "zstd", encoding)
+	assert.Less(t, len(payload), len(data))
+
+	payload, encoding, err = compress("gzip", []byte("small"))
+	assert.Nil(t, err)
+	assert.Equal(t, "", encoding)
+	assert.Equal(t, []byte("small"), payload)
+
+	payload, encoding, err = compress("none", data)
+	assert.Nil(t, err)
+	assert.Equal(t, "", encoding)
+	assert.Equal(t, data, payload)
+
+	_, _, err = compress("br", data)
+	assert.Equal(t, "unsupported compression: br", err.Error())
+}
+
+func TestClient_SupportedCompression_FallsBackForUnknownEncodings(t *testing.T) {
+	assert.Equal(t, "gzip", supportedCompression("gzip"))
+	assert.Equal(t, "zstd", supportedCompression("zstd"))
+	assert.Equal(t, "", supportedCompression("gzipp"))
+
+	data := []byte(strings.Repeat("a", minCompressionSize))
+	payload, encoding, err := compress(supportedCompression("gzipp"), data)
+	assert.Nil(t, err)
+	assert.Equal(t, "", encoding)
+	assert.Equal(t, data, payload)
+}
+
+func TestClient_Enroll_StoresSecret(t *testing.T) {
+	var paths, bodies, secrets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, string(body))
+		secrets = append(secrets, r.Header.Get("Agent-Secret"))
+		w.Write([]byte(`{"Data":[{"secret":"secret"}],"StatusCode":200,"Success":true}`))
+	}))
+	defer server.Close()
+	agentInformation := types.AgentInformation{ID: uuid.New()}
+	store := new(mocks.Store)
+	store.On("SetAgentInformation", types.AgentInformation{ID: agentInformation.ID, Secret: "secret"}).Return(nil)
+	client := &standardClient{
+		baseURL:          server.URL + "/",
+		httpClient:       server.Client(),
+		breaker:          newCircuitBreaker(0, 0),
+		store:            store,
+		agentInformation: agentInformation,
+	}
+
+	assert.Nil(t, client.Enroll("token"))
+	assert.Nil(t, client.Enroll("token"))
+	client.Post("health/", strings.NewReader("{}"))
+
+	assert.Equal(t, []string{"/agents/enroll", "/config/", "/health/"}, paths)
+	assert.JSONEq(t, `{"token":"token"}`, bodies[0])
+	assert.Equal(t, []string{"", "secret", "secret"}, secrets)
+	store.AssertExpectations(t)
+}
+
+func TestClient_Enroll_EnrollsAgainWhenSecretIsRejected(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/config/" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"Data":[{"secret":"new secret"}],"StatusCode":200,"Success":true}`))
+	}))
+	defer server.Close()
+	agentInformation := types.AgentInformation{ID: uuid.New(), Secret: "revoked secret"}
+	store := new(mocks.Store)
+	store.On("SetAgentInformation", types.AgentInformation{ID: agentInformation.ID, Secret: "new secret"}).Return(nil)
+	client := &standardClient{
+		baseURL:          server.URL + "/",
+		httpClient:       server.Client(),
+		breaker:          newCircuitBreaker(0, 0),
+		store:            store,
+		agentInformation: agentInformation,
+	}
+
+	assert.Nil(t, client.Enroll(""))
+	assert.Empty(t, paths)
+	assert.Nil(t, client.Enroll("token"))
+
+	assert.Equal(t, []string{"/config/", "/agents/enroll"}, paths)
+	store.AssertExpectations(t)
+}
+
+func TestClient_Enroll_ReturnsRejection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"Data":[],"StatusCode":401,"Error":"enrollment token is invalid","Success":false}`))
+	}))
+	defer server.Close()
+	store := new(mocks.Store)
+	client := &standardClient{
+		baseURL:    server.URL + "/",
+		httpClient: server.Client(),
+		breaker:    newCircuitBreaker(0, 0),
+		store:      store,
+	}
+
+	err := client.Enroll("token")
+
+	assert.Equal(t, "failed to enroll with status code 401 (enrollment token is invalid)", err.Error())
+	assert.NotNil(t, client.Enroll(""))
+	store.AssertNotCalled(t, "SetAgentInformation")
+}
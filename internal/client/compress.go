@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// minCompressionSize is the smallest payload which is worth compressing
+	minCompressionSize = 1024
+)
+
+// supportedCompression returns the encoding if it is supported, unknown encodings fall back to
+// no compression with a warning so a typo does not fail every request
+func supportedCompression(encoding string) string {
+	switch encoding {
+	case "gzip", "zstd", "", "none":
+		return encoding
+	}
+	logger.Instance().Warningf("unsupported compression: %s, sending payloads uncompressed", encoding)
+	return ""
+}
+
+// compress returns the payload compressed with a given encoding (gzip or zstd) and the
+// Content-Encoding to send it with. Small payloads are returned as is
+func compress(encoding string, payload []byte) ([]byte, string, error) {
+	if len(payload) < minCompressionSize {
+		return payload, "", nil
+	}
+
+	switch encoding {
+	case "gzip":
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(payload); err != nil {
+			return nil, "", err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), encoding, nil
+	case "zstd":
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, "", err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(payload, nil), encoding, nil
+	case "", "none":
+		return payload, "", nil
+	}
+	return nil, "", fmt.Errorf("unsupported compression: %s", encoding)
+}
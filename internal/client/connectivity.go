@@ -0,0 +1,91 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+)
+
+var (
+	connectivityTimeout = time.Second * 10
+)
+
+// CheckConnectivity verifies that the API can be reached, that its certificate is trusted and that it
+// accepts the credentials of this agent. The outcome of every step is written to w and the check stops
+// at the first step which fails. Returns true when every step succeeded
+func CheckConnectivity(baseURL string, w io.Writer) bool {
+	var c Client
+	tlsConfig, err := newTLSConfig()
+	if err == nil {
+		c, err = NewClient(baseURL)
+	}
+	if !report(w, err, "Loading the certificates from %s", utils.GetVariable(consts.CERT_DIR)) {
+		return false
+	}
+	return checkConnectivity(baseURL, tlsConfig, c, w)
+}
+
+// checkConnectivity runs the steps of CheckConnectivity with a given TLS config and client
+func checkConnectivity(baseURL string, tlsConfig *tls.Config, c Client, w io.Writer) bool {
+	parsed, err := url.Parse(baseURL)
+	if err == nil && parsed.Host == "" {
+		err = fmt.Errorf("the url has no host")
+	}
+	if !report(w, err, "Parsing the API url %s", baseURL) {
+		return false
+	}
+	address := parsed.Host
+	if parsed.Port() == "" {
+		address = net.JoinHostPort(parsed.Hostname(), map[string]string{"http": "80", "https": "443"}[parsed.Scheme])
+	}
+
+	connection, err := net.DialTimeout("tcp", address, connectivityTimeout)
+	if !report(w, err, "Reaching %s", address) {
+		return false
+	}
+	connection.Close()
+
+	if parsed.Scheme == "https" {
+		config := tlsConfig.Clone()
+		config.ServerName = parsed.Hostname()
+		connection, err := tls.DialWithDialer(&net.Dialer{Timeout: connectivityTimeout}, "tcp", address, config)
+		var unknownAuthority x509.UnknownAuthorityError
+		if errors.As(err, &unknownAuthority) {
+			err = fmt.Errorf("%s, the certificate is not signed by the authority in %s", err.Error(), consts.CLIENT_CERT)
+		}
+		if !report(w, err, "Verifying the certificate of %s", parsed.Hostname()) {
+			return false
+		}
+		certificate := connection.ConnectionState().PeerCertificates[0]
+		fmt.Fprintf(w, "       subject %s, issued by %s, expires %s\n",
+			certificate.Subject.String(), certificate.Issuer.String(), certificate.NotAfter.UTC().Format(time.RFC3339))
+		connection.Close()
+	}
+
+	_, statusCode, err := c.Get("config/")
+	if err == nil && (statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden) {
+		err = fmt.Errorf("status code %d, the agent is not enrolled or was revoked", statusCode)
+	} else if err == nil && statusCode != http.StatusOK {
+		err = fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	return report(w, err, "Authenticating with the API")
+}
+
+// report writes the outcome of a step and returns if it succeeded
+func report(w io.Writer, err error, format string, args ...interface{}) bool {
+	if err != nil {
+		fmt.Fprintf(w, "[FAIL] %s: %s\n", fmt.Sprintf(format, args...), err.Error())
+		return false
+	}
+	fmt.Fprintf(w, "[ OK ] %s\n", fmt.Sprintf(format, args...))
+	return true
+}
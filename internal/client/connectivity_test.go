@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getConnectivityServer(t *testing.T, statusCode int) (*httptest.Server, *standardClient, *tls.Config) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return server, &standardClient{
+		baseURL:    server.URL + "/",
+		httpClient: server.Client(),
+		breaker:    newCircuitBreaker(2, time.Minute),
+	}, &tls.Config{RootCAs: pool}
+}
+
+func TestClient_CheckConnectivity_SucceedsForTrustedAPI(t *testing.T) {
+	server, client, tlsConfig := getConnectivityServer(t, http.StatusOK)
+	output := new(bytes.Buffer)
+
+	assert.True(t, checkConnectivity(server.URL+"/", tlsConfig, client, output))
+	assert.NotContains(t, output.String(), "[FAIL]")
+	assert.Contains(t, output.String(), "[ OK ] Verifying the certificate of 127.0.0.1")
+	assert.Contains(t, output.String(), "[ OK ] Authenticating with the API")
+}
+
+func TestClient_CheckConnectivity_ReportsUntrustedCertificate(t *testing.T) {
+	server, client, _ := getConnectivityServer(t, http.StatusOK)
+	output := new(bytes.Buffer)
+
+	assert.False(t, checkConnectivity(server.URL+"/", &tls.Config{RootCAs: x509.NewCertPool()}, client, output))
+	assert.Contains(t, output.String(), "[FAIL] Verifying the certificate of 127.0.0.1")
+	assert.Contains(t, output.String(), "not signed by the authority in CLIENT_CERT")
+	assert.NotContains(t, output.String(), "Authenticating")
+}
+
+func TestClient_CheckConnectivity_ReportsRejectedCredentials(t *testing.T) {
+	server, client, tlsConfig := getConnectivityServer(t, http.StatusUnauthorized)
+	output := new(bytes.Buffer)
+
+	assert.False(t, checkConnectivity(server.URL+"/", tlsConfig, client, output))
+	assert.Contains(t, output.String(), "[FAIL] Authenticating with the API: status code 401")
+}
+
+func TestClient_CheckConnectivity_ReportsUnreachableAPI(t *testing.T) {
+	server, client, tlsConfig := getConnectivityServer(t, http.StatusOK)
+	server.Close()
+	output := new(bytes.Buffer)
+
+	assert.False(t, checkConnectivity(server.URL+"/", tlsConfig, client, output))
+	assert.Contains(t, output.String(), "[FAIL] Reaching 127.0.0.1")
+
+	output.Reset()
+	assert.False(t, checkConnectivity("not a url", tlsConfig, client, output))
+	assert.Contains(t, output.String(), "[FAIL] Parsing the API url not a url")
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+)
+
+// dryRunClient logs the payload of every request instead of sending it to the API
+type dryRunClient struct{}
+
+var (
+	_ Client = (*dryRunClient)(nil)
+)
+
+// NewDryRunClient returns a client which never contacts the API. Every POST is logged and
+// reported as accepted, while GET requests fail as there is no response to return
+func NewDryRunClient() Client {
+	return &dryRunClient{}
+}
+
+// Get logs the URL and returns ErrDryRun
+func (c *dryRunClient) Get(url string) ([]byte, int, error) {
+	logger.Instance().Infof("Dry run, not sending GET %s", url)
+	return nil, 0, ErrDryRun
+}
+
+// Post logs the URL and payload and returns a successful response
+func (c *dryRunClient) Post(url string, data io.Reader) ([]byte, int, error) {
+	var payload []byte
+	if data != nil {
+		var err error
+		if payload, err = ioutil.ReadAll(data); err != nil {
+			return nil, 0, &RequestError{Err: err}
+		}
+	}
+	logger.Instance().Infof("Dry run, not sending POST %s with payload: %s", url, bytes.TrimSpace(payload))
+	return []byte("{}"), http.StatusOK, nil
+}
+
+// Enroll does nothing as a dry run does not need credentials
+func (c *dryRunClient) Enroll(token string) error {
+	return nil
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DryRun_PostSucceedsWithoutSending(t *testing.T) {
+	client := NewDryRunClient()
+
+	body, statusCode, err := client.Post("health/batch", strings.NewReader(`[{"uptime": 1}]`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "{}", string(body))
+	assert.Nil(t, client.Enroll(""))
+}
+
+func TestClient_DryRun_GetReturnsError(t *testing.T) {
+	_, _, err := NewDryRunClient().Get("config/")
+
+	assert.ErrorIs(t, err, ErrDryRun)
+}
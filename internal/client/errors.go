@@ -0,0 +1,51 @@
+package client
+
+import (
+	"errors"
+)
+
+var (
+	// ErrCircuitOpen is returned without making a request while the API is considered unreachable
+	ErrCircuitOpen = errors.New("circuit breaker is open, not sending request")
+	// ErrDryRun is returned by requests which need a response from the API during a dry run
+	ErrDryRun = errors.New("dry run, not sending request")
+)
+
+// RequestError is returned when a request could not be created
+type RequestError struct {
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return "failed to create request: " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// TransportError is returned when a request could not be sent or no response was received
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return "failed to send request: " + e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseError is returned when the body of a response could not be read
+type ResponseError struct {
+	Err error
+}
+
+func (e *ResponseError) Error() string {
+	return "failed to read response: " + e.Err.Error()
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
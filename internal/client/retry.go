@@ -0,0 +1,71 @@
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	sleep  = time.Sleep
+	now    = time.Now
+	random = rand.Float64
+)
+
+// RetryPolicy contains how often and how long to wait before a failed request is retried
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// delay returns the jittered exponential backoff before a given retry, or the Retry-After
+// of the response if it is longer. False is returned if Retry-After is longer than the max delay
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > p.MaxDelay {
+		return 0, false
+	}
+
+	backoff := p.BaseDelay << attempt
+	if backoff > p.MaxDelay || backoff <= 0 {
+		backoff = p.MaxDelay
+	}
+	delay := time.Duration(random() * float64(backoff))
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay, true
+}
+
+// isRetryable returns if sending the same request again could succeed
+func isRetryable(statusCode int, err error) bool {
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
+
+// parseRetryAfter returns the duration of a Retry-After header given in seconds or as a HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Second * time.Duration(seconds)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now()); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -33,8 +33,66 @@ const (
 	MINUTES_TO_INCLUDE_HEALTH = "MINUTES_TO_INCLUDE_HEALTH"
 	// DATA_WEBSOCKET_DELAY is a key used to lookup the delay (in seconds) between sending more data via websockets (Used by: api)
 	DATA_WEBSOCKET_DELAY = "DATA_WEBSOCKET_DELAY"
-	// DC_HEALTH_DELAY is a key used to lookup the delay (in seconds) between sending helath information from data-collector to api (Used by: api)
+	// DC_HEALTH_DELAY is a key used to lookup the delay (in seconds) between sending helath information from data-collector to api (Used by: data-collector)
 	DC_HEALTH_DELAY = "DC_HEALTH_DELAY"
+	// DISK_INCLUDE_FSTYPES is a key used to lookup the comma separated filesystem type patterns to report disk usage for, empty includes all (Used by: data-collector)
+	DISK_INCLUDE_FSTYPES = "DISK_INCLUDE_FSTYPES"
+	// DISK_EXCLUDE_FSTYPES is a key used to lookup the comma separated filesystem type patterns to never report disk usage for (Used by: data-collector)
+	DISK_EXCLUDE_FSTYPES = "DISK_EXCLUDE_FSTYPES"
+	// PROCESS_SNAPSHOT_ENABLED is a key used to lookup if the top process snapshot should be collected (Used by: data-collector)
+	PROCESS_SNAPSHOT_ENABLED = "PROCESS_SNAPSHOT_ENABLED"
+	// PROCESS_SNAPSHOT_COUNT is a key used to lookup the amount of processes included in each top process list (Used by: data-collector)
+	PROCESS_SNAPSHOT_COUNT = "PROCESS_SNAPSHOT_COUNT"
+	// AGENT_CONFIG_FILE is a key used to lookup the location of the agent config file, which contains check, probe, certificate and log definitions (Used by: data-collector)
+	AGENT_CONFIG_FILE = "AGENT_CONFIG_FILE"
+	// BUFFER_FILE is a key used to lookup the location of the file which holds health data that could not be sent to the api (Used by: data-collector)
+	BUFFER_FILE = "BUFFER_FILE"
+	// BUFFER_MAX_ENTRIES is a key used to lookup the maximum amount of unsent health data kept, the oldest is dropped first (Used by: data-collector)
+	BUFFER_MAX_ENTRIES = "BUFFER_MAX_ENTRIES"
+	// BUFFER_MAX_AGE is a key used to lookup the age (in minutes) after which unsent health data is dropped (Used by: data-collector)
+	BUFFER_MAX_AGE = "BUFFER_MAX_AGE"
+	// HEALTH_BATCH_SIZE is a key used to lookup the amount of health samples collected before they are sent to the api together (Used by: data-collector)
+	HEALTH_BATCH_SIZE = "HEALTH_BATCH_SIZE"
+	// HEALTH_BATCH_MAX_AGE is a key used to lookup the age (in seconds) of the oldest collected health sample after which the batch is sent, even when not full (Used by: data-collector)
+	HEALTH_BATCH_MAX_AGE = "HEALTH_BATCH_MAX_AGE"
+	// CLIENT_COMPRESSION is a key used to lookup the compression (gzip, zstd or none) used for data sent to the api (Used by: data-collector)
+	CLIENT_COMPRESSION = "CLIENT_COMPRESSION"
+	// MAX_DECOMPRESSED_SIZE is a key used to lookup the maximum size (in bytes) of a compressed request body once decompressed (Used by: api)
+	MAX_DECOMPRESSED_SIZE = "MAX_DECOMPRESSED_SIZE"
+	// CLIENT_MAX_RETRIES is a key used to lookup how often a failed request to the api is retried (Used by: data-collector)
+	CLIENT_MAX_RETRIES = "CLIENT_MAX_RETRIES"
+	// CLIENT_RETRY_BASE_DELAY is a key used to lookup the delay (in milliseconds) before the first retry, doubling on each retry (Used by: data-collector)
+	CLIENT_RETRY_BASE_DELAY = "CLIENT_RETRY_BASE_DELAY"
+	// CLIENT_RETRY_MAX_DELAY is a key used to lookup the maximum delay (in milliseconds) between retries (Used by: data-collector)
+	CLIENT_RETRY_MAX_DELAY = "CLIENT_RETRY_MAX_DELAY"
+	// CLIENT_BREAKER_THRESHOLD is a key used to lookup the amount of consecutive failed requests after which no requests are made, 0 disables it (Used by: data-collector)
+	CLIENT_BREAKER_THRESHOLD = "CLIENT_BREAKER_THRESHOLD"
+	// CLIENT_BREAKER_COOLDOWN is a key used to lookup the delay (in seconds) before a request is tried again once the breaker opened (Used by: data-collector)
+	CLIENT_BREAKER_COOLDOWN = "CLIENT_BREAKER_COOLDOWN"
+	// AGENT_GROUP is a key used to lookup the group whose remote configuration applies to the agent (Used by: data-collector)
+	AGENT_GROUP = "AGENT_GROUP"
+	// REMOTE_CONFIG_INTERVAL is a key used to lookup the delay (in seconds) between fetching the remote configuration from the api (Used by: data-collector)
+	REMOTE_CONFIG_INTERVAL = "REMOTE_CONFIG_INTERVAL"
+	// ENROLLMENT_TOKEN is a key used to lookup the one-time token the agent exchanges for its secret when it is not enrolled yet or its secret is rejected (Used by: data-collector)
+	ENROLLMENT_TOKEN = "ENROLLMENT_TOKEN"
+	// ADMIN_API_KEY is a key used to lookup the key which authorizes managing the remote configuration, creating enrollment tokens and revoking agents, empty disables all of them (Used by: api)
+	ADMIN_API_KEY = "ADMIN_API_KEY"
+	// CLIENT_CA is a key used to lookup the file name of the CA in CERT_DIR which agent client certificates are verified against, empty disables mutual TLS (Used by: api)
+	CLIENT_CA = "CLIENT_CA"
+	// CERT_RELOAD_INTERVAL is a key used to lookup the delay (in seconds) between checking the api certificate files for changes, 0 only reloads on SIGHUP (Used by: api)
+	CERT_RELOAD_INTERVAL = "CERT_RELOAD_INTERVAL"
+	// AGENT_CERT is a key used to lookup the file name of the client certificate in CERT_DIR the agent presents, empty disables it (Used by: data-collector)
+	AGENT_CERT = "AGENT_CERT"
+	// AGENT_KEY is a key used to lookup the file name of the private key of AGENT_CERT in CERT_DIR (Used by: data-collector)
+	AGENT_KEY = "AGENT_KEY"
+	// STATUS_ADDRESS is a key used to lookup the address the local /metrics and /status endpoints listen on, empty disables them (Used by: data-collector)
+	STATUS_ADDRESS = "STATUS_ADDRESS"
+	// SHUTDOWN_TIMEOUT is a key used to lookup how long (in seconds) in-flight requests and pending data are given to finish once asked to stop (Used by: api/data-collector)
+	SHUTDOWN_TIMEOUT = "SHUTDOWN_TIMEOUT"
+	// HOST_INVENTORY_INTERVAL is a key used to lookup the delay (in seconds) between collecting the host information, which is only sent when it changed (Used by: data-collector)
+	HOST_INVENTORY_INTERVAL = "HOST_INVENTORY_INTERVAL"
+	// CONFIG_FILE is a key used to lookup the location of a JSON or YAML file containing values for any of these keys (Used by: api/data-collector)
+	CONFIG_FILE = "CONFIG_FILE"
 	// Constant filenames
 
 	// AGENT_STORE_FILENAME is the file location of agent filestore (Used by: data-collector)
@@ -46,4 +104,143 @@ const (
 	COLLECTION_HEALTH = "health"
 	// COLLECTION_HOST is the collection name used for the host collection (Used by: api)
 	COLLECTION_HOST = "host"
+	// COLLECTION_HOST_HISTORY is the collection name used for the host inventory change collection (Used by: api)
+	COLLECTION_HOST_HISTORY = "host_history"
+	// COLLECTION_REBOOT is the collection name used for the host reboot collection (Used by: api)
+	COLLECTION_REBOOT = "reboot"
+	// COLLECTION_PROCESS is the collection name used for the process snapshot collection (Used by: api)
+	COLLECTION_PROCESS = "process"
+	// COLLECTION_CHECK is the collection name used for the check result collection (Used by: api)
+	COLLECTION_CHECK = "check"
+	// COLLECTION_PROBE is the collection name used for the probe result collection (Used by: api)
+	COLLECTION_PROBE = "probe"
+	// COLLECTION_CERTIFICATE is the collection name used for the certificate collection (Used by: api)
+	COLLECTION_CERTIFICATE = "certificate"
+	// COLLECTION_EVENT is the collection name used for the log event collection (Used by: api)
+	COLLECTION_EVENT = "event"
+	// COLLECTION_REMOTE_CONFIG is the collection name used for the remote agent configuration collection (Used by: api)
+	COLLECTION_REMOTE_CONFIG = "remoteConfig"
+	// COLLECTION_ENROLLMENT_TOKEN is the collection name used for the enrollment token collection (Used by: api)
+	COLLECTION_ENROLLMENT_TOKEN = "enrollmentToken"
+	// COLLECTION_AGENT is the collection name used for the enrolled agent collection (Used by: api)
+	COLLECTION_AGENT = "agent"
+
+	// Constant check statuses
+
+	// CHECK_STATUS_OK is the status of a check which exited with 0
+	CHECK_STATUS_OK = "OK"
+	// CHECK_STATUS_WARNING is the status of a check which exited with 1
+	CHECK_STATUS_WARNING = "WARNING"
+	// CHECK_STATUS_CRITICAL is the status of a check which exited with 2
+	CHECK_STATUS_CRITICAL = "CRITICAL"
+	// CHECK_STATUS_UNKNOWN is the status of a check which exited with 3, any other code, or failed to run
+	CHECK_STATUS_UNKNOWN = "UNKNOWN"
+
+	// Constant probe types
+
+	// PROBE_TYPE_HTTP is the type of a probe which makes a HTTP(S) request
+	PROBE_TYPE_HTTP = "http"
+	// PROBE_TYPE_TCP is the type of a probe which opens a TCP connection
+	PROBE_TYPE_TCP = "tcp"
+
+	// Constant remote configuration scopes
+
+	// SCOPE_AGENT is the scope of remote configuration which applies to a single agent
+	SCOPE_AGENT = "agent"
+	// SCOPE_GROUP is the scope of remote configuration which applies to every agent in a group
+	SCOPE_GROUP = "group"
+
+	// Constant collector names
+
+	// COLLECTOR_CPU is the name of the cpu utilization collector
+	COLLECTOR_CPU = "cpu"
+	// COLLECTOR_MEMORY is the name of the memory collector
+	COLLECTOR_MEMORY = "memory"
+	// COLLECTOR_DISK is the name of the disk usage collector
+	COLLECTOR_DISK = "disk"
+	// COLLECTOR_DISK_IO is the name of the disk I/O rate collector
+	COLLECTOR_DISK_IO = "diskio"
+	// COLLECTOR_NETWORK is the name of the network rate collector
+	COLLECTOR_NETWORK = "network"
+	// COLLECTOR_LOAD is the name of the load average collector
+	COLLECTOR_LOAD = "load"
+	// COLLECTOR_SENSOR is the name of the temperature and fan collector
+	COLLECTOR_SENSOR = "sensor"
+	// COLLECTOR_PROCESS is the name of the top process snapshot collector
+	COLLECTOR_PROCESS = "process"
+)
+
+var (
+	// VERSION is the version of the binaries, it is set at build time with
+	// -ldflags "-X github.com/PR-Developers/server-health-monitor/internal/consts.VERSION=<version>"
+	VERSION = "dev"
+
+	// KEYS contains every constant key in the order they are declared
+	KEYS = []string{
+		API_URL,
+		WS_URL,
+		API_PORT,
+		CERT_DIR,
+		CLIENT_CERT,
+		API_CERT,
+		API_KEY,
+		DB_URI,
+		DB_NAME,
+		DB_USER,
+		DB_PASS,
+		LOG_FILE,
+		MINUTES_SINCE_HEALTH_SHOW_OFFLINE,
+		MINUTES_TO_INCLUDE_HEALTH,
+		DATA_WEBSOCKET_DELAY,
+		DC_HEALTH_DELAY,
+		DISK_INCLUDE_FSTYPES,
+		DISK_EXCLUDE_FSTYPES,
+		PROCESS_SNAPSHOT_ENABLED,
+		PROCESS_SNAPSHOT_COUNT,
+		AGENT_CONFIG_FILE,
+		BUFFER_FILE,
+		BUFFER_MAX_ENTRIES,
+		BUFFER_MAX_AGE,
+		HEALTH_BATCH_SIZE,
+		HEALTH_BATCH_MAX_AGE,
+		CLIENT_COMPRESSION,
+		MAX_DECOMPRESSED_SIZE,
+		CLIENT_MAX_RETRIES,
+		CLIENT_RETRY_BASE_DELAY,
+		CLIENT_RETRY_MAX_DELAY,
+		CLIENT_BREAKER_THRESHOLD,
+		CLIENT_BREAKER_COOLDOWN,
+		AGENT_GROUP,
+		REMOTE_CONFIG_INTERVAL,
+		ENROLLMENT_TOKEN,
+		ADMIN_API_KEY,
+		CLIENT_CA,
+		CERT_RELOAD_INTERVAL,
+		AGENT_CERT,
+		AGENT_KEY,
+		STATUS_ADDRESS,
+		SHUTDOWN_TIMEOUT,
+		HOST_INVENTORY_INTERVAL,
+		CONFIG_FILE,
+	}
+
+	// COLLECTORS contains every collector name
+	COLLECTORS = []string{
+		COLLECTOR_CPU,
+		COLLECTOR_MEMORY,
+		COLLECTOR_DISK,
+		COLLECTOR_DISK_IO,
+		COLLECTOR_NETWORK,
+		COLLECTOR_LOAD,
+		COLLECTOR_SENSOR,
+		COLLECTOR_PROCESS,
+	}
+
+	// SECRET_KEYS contains the constant keys whose values must never be shown
+	SECRET_KEYS = []string{
+		DB_PASS,
+		DB_URI,
+		ENROLLMENT_TOKEN,
+		ADMIN_API_KEY,
+	}
 )
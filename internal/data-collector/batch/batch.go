@@ -0,0 +1,64 @@
+package batch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+var (
+	now = time.Now
+)
+
+// Batch accumulates health samples until enough were collected or the oldest sample is too old
+type Batch struct {
+	size    int
+	maxAge  time.Duration
+	samples []types.Health
+	started time.Time
+	lock    sync.Mutex
+}
+
+// New returns an empty batch which is ready after size samples or once the first sample is maxAge old
+func New(size int, maxAge time.Duration) *Batch {
+	return &Batch{
+		size:    size,
+		maxAge:  maxAge,
+		samples: []types.Health{},
+	}
+}
+
+// Add adds a sample to the batch. When the batch is ready its samples are returned and the
+// batch is emptied, otherwise nil is returned
+func (b *Batch) Add(sample types.Health) []types.Health {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(b.samples) == 0 {
+		b.started = now()
+	}
+	b.samples = append(b.samples, sample)
+
+	if len(b.samples) < b.size && now().Sub(b.started) < b.maxAge {
+		return nil
+	}
+
+	samples := b.samples
+	b.samples = []types.Health{}
+	return samples
+}
+
+// Flush returns the samples of the batch whether it is ready or not and empties it. nil is returned
+// if the batch is empty
+func (b *Batch) Flush() []types.Health {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(b.samples) == 0 {
+		return nil
+	}
+	samples := b.samples
+	b.samples = []types.Health{}
+	return samples
+}
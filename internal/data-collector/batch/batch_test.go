@@ -0,0 +1,50 @@
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Add_ReturnsSamplesWhenFull(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	batch := New(3, time.Minute)
+
+	assert.Nil(t, batch.Add(types.Health{Uptime: 1}))
+	assert.Nil(t, batch.Add(types.Health{Uptime: 2}))
+	samples := batch.Add(types.Health{Uptime: 3})
+
+	assert.Equal(t, []types.Health{{Uptime: 1}, {Uptime: 2}, {Uptime: 3}}, samples)
+	assert.Nil(t, batch.Add(types.Health{Uptime: 4}))
+}
+
+func TestBatch_Add_ReturnsSamplesWhenOldestIsTooOld(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	batch := New(10, time.Minute)
+
+	assert.Nil(t, batch.Add(types.Health{Uptime: 1}))
+	now = func() time.Time { return time.Unix(1060, 0) }
+	samples := batch.Add(types.Health{Uptime: 2})
+
+	assert.Equal(t, []types.Health{{Uptime: 1}, {Uptime: 2}}, samples)
+}
+
+func TestBatch_Add_ReturnsEverySampleWithSizeOne(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	batch := New(1, time.Minute)
+
+	assert.Equal(t, []types.Health{{Uptime: 1}}, batch.Add(types.Health{Uptime: 1}))
+}
+
+func TestBatch_Flush_ReturnsPendingSamples(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	batch := New(10, time.Minute)
+
+	assert.Nil(t, batch.Flush())
+	assert.Nil(t, batch.Add(types.Health{Uptime: 1}))
+
+	assert.Equal(t, []types.Health{{Uptime: 1}}, batch.Flush())
+	assert.Nil(t, batch.Flush())
+}
@@ -0,0 +1,149 @@
+package buffer
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/store"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+)
+
+var (
+	now = time.Now
+)
+
+// Entry is a single packet which could not be sent to the API
+type Entry struct {
+	URL       string          `json:"url"`
+	Data      json.RawMessage `json:"data"`
+	QueueTime int64           `json:"queueTime"`
+}
+
+// Buffer is a disk backed queue which holds packets until they can be sent to the API
+type Buffer struct {
+	store      store.Store
+	maxEntries int
+	maxAge     time.Duration
+	entries    []Entry
+	lock       sync.Mutex
+	// dropped counts the entries removed from the front without being sent
+	dropped int
+	// replayLock keeps replays from sending the same entries twice, it is not held by Add
+	replayLock sync.Mutex
+}
+
+// New returns a buffer persisted in a given store, containing any entries left over from a previous run.
+// At most maxEntries are kept (dropping the oldest first) and entries older than maxAge are dropped
+func New(store store.Store, maxEntries int, maxAge time.Duration) *Buffer {
+	buffer := &Buffer{
+		store:      store,
+		maxEntries: maxEntries,
+		maxAge:     maxAge,
+		entries:    []Entry{},
+	}
+
+	data, err := store.Get()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return buffer
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &buffer.entries); err != nil {
+			logger.Instance().Errorf("discarding unreadable buffer (%s)", err.Error())
+			buffer.entries = []Entry{}
+		}
+	}
+	return buffer
+}
+
+// Add queues a packet at the end of the buffer
+func (b *Buffer) Add(url string, data []byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.entries = append(b.entries, Entry{
+		URL:       url,
+		Data:      data,
+		QueueTime: now().UTC().UnixNano(),
+	})
+	b.dropExpired()
+	if dropped := len(b.entries) - b.maxEntries; dropped > 0 {
+		logger.Instance().Warningf("dropping %d of the oldest buffered packets as the buffer is full", dropped)
+		b.entries = b.entries[dropped:]
+		b.dropped += dropped
+	}
+	b.persist()
+}
+
+// Replay sends every buffered packet in the order it was added, stopping at the first packet
+// which send fails to deliver. Packets can be added while it is sending. Returns true when the
+// buffer is empty afterwards
+func (b *Buffer) Replay(send func(url string, data []byte) bool) bool {
+	b.replayLock.Lock()
+	defer b.replayLock.Unlock()
+
+	b.lock.Lock()
+	if len(b.entries) == 0 {
+		b.lock.Unlock()
+		return true
+	}
+	b.dropExpired()
+	pending := append([]Entry{}, b.entries...)
+	dropped := b.dropped
+	b.lock.Unlock()
+
+	sent := 0
+	for _, entry := range pending {
+		if !send(entry.URL, entry.Data) {
+			break
+		}
+		sent++
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if sent > 0 {
+		logger.Instance().Infof("replayed %d buffered packets", sent)
+		// Add may have dropped some of the sent entries from the front in the meantime
+		if remaining := sent - (b.dropped - dropped); remaining > 0 {
+			b.entries = b.entries[remaining:]
+		}
+		b.persist()
+	}
+	return len(b.entries) == 0
+}
+
+// Len returns the amount of buffered packets
+func (b *Buffer) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return len(b.entries)
+}
+
+// dropExpired removes every entry older than the max age
+func (b *Buffer) dropExpired() {
+	oldest := now().UTC().Add(-b.maxAge).UnixNano()
+	expired := 0
+	for expired < len(b.entries) && b.entries[expired].QueueTime < oldest {
+		expired++
+	}
+	if expired > 0 {
+		logger.Instance().Warningf("dropping %d buffered packets as they are older than %s", expired, b.maxAge)
+		b.entries = b.entries[expired:]
+		b.dropped += expired
+	}
+}
+
+// persist writes the entries to the store
+func (b *Buffer) persist() {
+	data, err := json.Marshal(b.entries)
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return
+	}
+	if err = b.store.Store(data); err != nil {
+		logger.Instance().Error(err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+package buffer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/store"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+	"github.com/stretchr/testify/assert"
+)
+
+type sent struct {
+	url  string
+	data string
+}
+
+func getInitializedBuffer(t *testing.T, maxEntries int) (*Buffer, string) {
+	file := filepath.Join(t.TempDir(), "buffer.json")
+	now = func() time.Time { return time.Unix(1000, 0) }
+	return New(store.NewFileStore(&wrapper.DefaultOS{}, file), maxEntries, time.Minute), file
+}
+
+func recordSends(delivered int) (*[]sent, func(url string, data []byte) bool) {
+	sends := []sent{}
+	return &sends, func(url string, data []byte) bool {
+		if len(sends) >= delivered {
+			return false
+		}
+		sends = append(sends, sent{url: url, data: string(data)})
+		return true
+	}
+}
+
+func TestBuffer_Replay_SendsInOrder(t *testing.T) {
+	buffer, _ := getInitializedBuffer(t, 10)
+	buffer.Add("health/", []byte(`{"createTime":1}`))
+	buffer.Add("health/", []byte(`{"createTime":2}`))
+
+	sends, send := recordSends(10)
+
+	assert.True(t, buffer.Replay(send))
+	assert.Equal(t, []sent{{"health/", `{"createTime":1}`}, {"health/", `{"createTime":2}`}}, *sends)
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestBuffer_Replay_StopsAtFirstFailure(t *testing.T) {
+	buffer, _ := getInitializedBuffer(t, 10)
+	for i := 1; i <= 3; i++ {
+		buffer.Add("health/", []byte(fmt.Sprintf(`{"createTime":%d}`, i)))
+	}
+
+	sends, send := recordSends(1)
+
+	assert.False(t, buffer.Replay(send))
+	assert.Equal(t, 1, len(*sends))
+	assert.Equal(t, 2, buffer.Len())
+	assert.Equal(t, `{"createTime":2}`, string(buffer.entries[0].Data))
+}
+
+func TestBuffer_Replay_AllowsAddingWhileSending(t *testing.T) {
+	buffer, _ := getInitializedBuffer(t, 2)
+	buffer.Add("health/", []byte(`{"createTime":1}`))
+	buffer.Add("health/", []byte(`{"createTime":2}`))
+
+	sends := []string{}
+	assert.False(t, buffer.Replay(func(url string, data []byte) bool {
+		if len(sends) == 0 {
+			// Fills the buffer, dropping the entry which is being sent
+			buffer.Add("health/", []byte(`{"createTime":3}`))
+		}
+		sends = append(sends, string(data))
+		return true
+	}))
+
+	assert.Equal(t, []string{`{"createTime":1}`, `{"createTime":2}`}, sends)
+	assert.Equal(t, 1, buffer.Len())
+	assert.Equal(t, `{"createTime":3}`, string(buffer.entries[0].Data))
+}
+
+func TestBuffer_Add_DropsOldestWhenFull(t *testing.T) {
+	buffer, _ := getInitializedBuffer(t, 2)
+	for i := 1; i <= 3; i++ {
+		buffer.Add("health/", []byte(fmt.Sprintf(`{"createTime":%d}`, i)))
+	}
+
+	assert.Equal(t, 2, buffer.Len())
+	assert.Equal(t, `{"createTime":2}`, string(buffer.entries[0].Data))
+}
+
+func TestBuffer_Replay_DropsExpiredEntries(t *testing.T) {
+	buffer, _ := getInitializedBuffer(t, 10)
+	buffer.Add("health/", []byte(`{"createTime":1}`))
+	now = func() time.Time { return time.Unix(1030, 0) }
+	buffer.Add("health/", []byte(`{"createTime":2}`))
+	now = func() time.Time { return time.Unix(1070, 0) }
+
+	sends, send := recordSends(10)
+
+	assert.True(t, buffer.Replay(send))
+	assert.Equal(t, []sent{{"health/", `{"createTime":2}`}}, *sends)
+}
+
+func TestBuffer_New_LoadsPersistedEntries(t *testing.T) {
+	buffer, file := getInitializedBuffer(t, 10)
+	buffer.Add("health/", []byte(`{"createTime":1}`))
+
+	buffer = New(store.NewFileStore(&wrapper.DefaultOS{}, file), 10, time.Minute)
+
+	assert.Equal(t, 1, buffer.Len())
+	assert.Equal(t, "health/", buffer.entries[0].URL)
+}
+
+func TestBuffer_New_HandlesUnreadableFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "buffer.json")
+	assert.Nil(t, os.WriteFile(file, []byte("not json"), 0644))
+
+	buffer := New(store.NewFileStore(&wrapper.DefaultOS{}, file), 10, time.Minute)
+
+	assert.Equal(t, 0, buffer.Len())
+}
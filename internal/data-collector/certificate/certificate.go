@@ -0,0 +1,165 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+const (
+	defaultInterval = 3600
+	dialTimeout     = time.Second * 10
+)
+
+var (
+	osWrapper wrapper.OperatingSystem = &wrapper.DefaultOS{}
+)
+
+// Start inspects every certificate source on its own interval in the background. Each time a source is inspected the
+// certificates of all sources are passed to send, so the api can drop sources which are no longer configured.
+// Calling the returned function stops all of them
+func Start(definitions []types.CertificateDefinition, send func(certificates []types.Certificate)) func() {
+	valid := []types.CertificateDefinition{}
+	for _, definition := range definitions {
+		if definition.File == "" && definition.Endpoint == "" {
+			logger.Instance().Warning("skipping certificate as it has no file or endpoint")
+			continue
+		}
+		valid = append(valid, definition)
+	}
+
+	var lock sync.Mutex
+	stopped := false
+	stops := []func(){}
+	latest := make([][]types.Certificate, len(valid))
+	// sendAll holds the lock while sending so a newer report is never overtaken by an older one
+	sendAll := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if stopped {
+			return
+		}
+		certificates := []types.Certificate{}
+		for _, sourceCertificates := range latest {
+			certificates = append(certificates, sourceCertificates...)
+		}
+		send(certificates)
+	}
+
+	go func() {
+		// every source is inspected once before the first report so it does not drop the sources which are still running
+		var wg sync.WaitGroup
+		for i := range valid {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				certificates := Run(valid[i])
+				lock.Lock()
+				latest[i] = certificates
+				lock.Unlock()
+			}(i)
+		}
+		wg.Wait()
+		sendAll()
+
+		lock.Lock()
+		defer lock.Unlock()
+		if stopped {
+			return
+		}
+		for i, definition := range valid {
+			interval := definition.Interval
+			if interval <= 0 {
+				interval = defaultInterval
+			}
+			i, definition := i, definition
+			stops = append(stops, scheduler.After(time.Second*time.Duration(interval), func() {
+				certificates := Run(definition)
+				lock.Lock()
+				latest[i] = certificates
+				lock.Unlock()
+				sendAll()
+			}))
+		}
+	}()
+
+	return func() {
+		lock.Lock()
+		defer lock.Unlock()
+		stopped = true
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// Run returns every certificate found in a PEM file or presented by a TLS endpoint. If the
+// source can not be read a single certificate containing the error is returned
+func Run(definition types.CertificateDefinition) []types.Certificate {
+	var certificates []types.Certificate
+	var err error
+	source := definition.File
+
+	if definition.File != "" {
+		certificates, err = readFile(definition.File)
+	} else {
+		source = definition.Endpoint
+		certificates, err = readEndpoint(definition.Endpoint)
+	}
+
+	if err != nil {
+		return []types.Certificate{
+			{
+				Source: source,
+				Error:  err.Error(),
+			},
+		}
+	}
+	return certificates
+}
+
+// readFile returns every certificate in a PEM file
+func readFile(file string) ([]types.Certificate, error) {
+	data, err := osWrapper.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ParseCertificates(data, file)
+}
+
+// readEndpoint returns the certificate chain presented by a TLS endpoint. The chain is
+// not verified as expired or untrusted certificates still need to be reported
+func readEndpoint(endpoint string) ([]types.Certificate, error) {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", endpoint, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	peerCertificates := connection.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, fmt.Errorf("no certificates presented by %s", endpoint)
+	}
+
+	certificates := make([]types.Certificate, 0, len(peerCertificates))
+	for _, certificate := range peerCertificates {
+		certificates = append(certificates, utils.GetCertificateInformation(certificate, endpoint))
+	}
+	return certificates, nil
+}
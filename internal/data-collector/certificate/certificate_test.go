@@ -0,0 +1,128 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/certificate/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name OperatingSystem
+
+func createTestCertificate(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    notAfter.Add(-time.Hour * 24 * 30),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificate_Run_ReadsFile(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "/etc/nginx/nginx.pem").Return(createTestCertificate(t, "example.com", notAfter), nil)
+
+	osWrapper = wrapper
+
+	certificates := Run(types.CertificateDefinition{File: "/etc/nginx/nginx.pem"})
+
+	assert.Equal(t, 1, len(certificates))
+	assert.Equal(t, "/etc/nginx/nginx.pem", certificates[0].Source)
+	assert.Equal(t, "CN=example.com", certificates[0].Subject)
+	assert.Equal(t, []string{"example.com"}, certificates[0].SANs)
+	assert.Equal(t, notAfter.UnixNano(), certificates[0].NotAfter)
+	assert.Equal(t, "", certificates[0].Error)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCertificate_Start_SendsEverySourceTogether(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "/etc/nginx/nginx.pem").Return(createTestCertificate(t, "example.com", notAfter), nil)
+	wrapper.On("ReadFile", "/missing.pem").Return(nil, fmt.Errorf("open /missing.pem: no such file or directory"))
+
+	osWrapper = wrapper
+
+	reports := make(chan []types.Certificate, 1)
+	stop := Start([]types.CertificateDefinition{
+		{File: "/etc/nginx/nginx.pem"},
+		{},
+		{File: "/missing.pem"},
+	}, func(certificates []types.Certificate) {
+		reports <- certificates
+	})
+	defer stop()
+
+	certificates := <-reports
+	assert.Equal(t, 2, len(certificates))
+	assert.Equal(t, "/etc/nginx/nginx.pem", certificates[0].Source)
+	assert.Equal(t, "/missing.pem", certificates[1].Source)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCertificate_Start_SendsEmptyReportWithoutSources(t *testing.T) {
+	reports := make(chan []types.Certificate, 1)
+	stop := Start([]types.CertificateDefinition{}, func(certificates []types.Certificate) {
+		reports <- certificates
+	})
+	defer stop()
+
+	assert.Equal(t, []types.Certificate{}, <-reports)
+}
+
+func TestCertificate_Run_HandlesFileError(t *testing.T) {
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "/missing.pem").Return(nil, fmt.Errorf("open /missing.pem: no such file or directory"))
+
+	osWrapper = wrapper
+
+	certificates := Run(types.CertificateDefinition{File: "/missing.pem"})
+
+	assert.Equal(t, []types.Certificate{
+		{Source: "/missing.pem", Error: "open /missing.pem: no such file or directory"},
+	}, certificates)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCertificate_Run_ReadsEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	endpoint := strings.TrimPrefix(server.URL, "https://")
+
+	certificates := Run(types.CertificateDefinition{Endpoint: endpoint})
+
+	assert.Equal(t, 1, len(certificates))
+	assert.Equal(t, endpoint, certificates[0].Source)
+	assert.Equal(t, server.Certificate().NotAfter.UnixNano(), certificates[0].NotAfter)
+	assert.Equal(t, "", certificates[0].Error)
+}
+
+func TestCertificate_Run_HandlesEndpointError(t *testing.T) {
+	certificates := Run(types.CertificateDefinition{Endpoint: "not-an-endpoint"})
+
+	assert.Equal(t, 1, len(certificates))
+	assert.Equal(t, "not-an-endpoint", certificates[0].Source)
+	assert.NotEqual(t, "", certificates[0].Error)
+}
@@ -0,0 +1,205 @@
+package check
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+const (
+	defaultInterval = 60
+	defaultTimeout  = 30
+)
+
+var (
+	executor wrapper.Executor = &wrapper.DefaultExecutor{}
+)
+
+// Start runs every check on its own interval in the background and passes each result to send.
+// Calling the returned function stops all of them
+func Start(definitions []types.CheckDefinition, send func(result types.CheckResult)) func() {
+	stops := []func(){}
+	for _, definition := range definitions {
+		if len(definition.Command) == 0 {
+			logger.Instance().Warningf("skipping check %s as it has no command", definition.Name)
+			continue
+		}
+
+		interval := definition.Interval
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+		definition := definition
+		stops = append(stops, scheduler.Every(time.Second*time.Duration(interval), func() {
+			send(Run(definition))
+		}))
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// Run executes a single check and interprets the result using the nagios plugin conventions
+func Run(definition types.CheckDefinition) types.CheckResult {
+	timeout := definition.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+
+	start := time.Now()
+	output, exitCode, err := executor.Execute(ctx, definition.Command[0], definition.Command[1:]...)
+	result := types.CheckResult{
+		Name:      definition.Name,
+		CheckTime: start.UTC().UnixNano(),
+		Duration:  time.Since(start).Milliseconds(),
+		ExitCode:  exitCode,
+		Status:    getStatus(exitCode),
+	}
+
+	if err != nil {
+		result.Status = consts.CHECK_STATUS_UNKNOWN
+		if ctx.Err() == context.DeadlineExceeded {
+			result.Output = "check timed out after " + strconv.Itoa(timeout) + " seconds"
+		} else {
+			result.Output = err.Error()
+		}
+		return result
+	}
+
+	result.Output, result.Perfdata = parseOutput(string(output))
+	return result
+}
+
+// getStatus converts a nagios plugin exit code to a status
+func getStatus(exitCode int) string {
+	switch exitCode {
+	case 0:
+		return consts.CHECK_STATUS_OK
+	case 1:
+		return consts.CHECK_STATUS_WARNING
+	case 2:
+		return consts.CHECK_STATUS_CRITICAL
+	}
+	return consts.CHECK_STATUS_UNKNOWN
+}
+
+// parseOutput splits nagios plugin output into its text and performance data. The first line
+// may contain perfdata after a "|", and any line after that may start more perfdata which
+// continues until the end of the output
+func parseOutput(output string) (string, []types.Perfdata) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	text := []string{}
+	perf := []string{}
+	inPerfdata := false
+
+	for i, line := range lines {
+		if inPerfdata {
+			perf = append(perf, line)
+			continue
+		}
+		if index := strings.Index(line, "|"); index >= 0 {
+			text = append(text, strings.TrimSpace(line[:index]))
+			perf = append(perf, line[index+1:])
+			// Only perfdata in the long output continues onto the following lines
+			inPerfdata = i > 0
+			continue
+		}
+		text = append(text, line)
+	}
+
+	return strings.TrimSpace(strings.Join(text, "\n")), parsePerfdata(strings.Join(perf, " "))
+}
+
+// parsePerfdata parses space separated 'label'=value[UOM];[warn];[crit];[min];[max] items
+func parsePerfdata(perfdata string) []types.Perfdata {
+	data := []types.Perfdata{}
+
+	for _, item := range splitPerfdata(perfdata) {
+		index := strings.LastIndex(item, "=")
+		if index <= 0 {
+			continue
+		}
+
+		label := strings.Trim(item[:index], "'")
+		fields := strings.Split(item[index+1:], ";")
+		value, uom := splitValue(fields[0])
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+
+		parsed := types.Perfdata{
+			Label: label,
+			Value: number,
+			UOM:   uom,
+		}
+		for i, field := range fields[1:] {
+			switch i {
+			case 0:
+				parsed.Warning = field
+			case 1:
+				parsed.Critical = field
+			case 2:
+				parsed.Min = field
+			case 3:
+				parsed.Max = field
+			}
+		}
+		data = append(data, parsed)
+	}
+
+	return data
+}
+
+// splitPerfdata splits perfdata on whitespace while keeping quoted labels together
+func splitPerfdata(perfdata string) []string {
+	items := []string{}
+	current := strings.Builder{}
+	quoted := false
+
+	for _, character := range perfdata {
+		switch {
+		case character == '\'':
+			quoted = !quoted
+			current.WriteRune(character)
+		case !quoted && (character == ' ' || character == '\t' || character == '\n'):
+			if current.Len() > 0 {
+				items = append(items, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(character)
+		}
+	}
+	if current.Len() > 0 {
+		items = append(items, current.String())
+	}
+	return items
+}
+
+// splitValue splits a perfdata value from its unit of measurement
+func splitValue(value string) (string, string) {
+	index := strings.IndexFunc(value, func(character rune) bool {
+		return !(character >= '0' && character <= '9') && character != '.' && character != '-' && character != '+' && character != 'e' && character != 'E'
+	})
+	if index < 0 {
+		return value, ""
+	}
+	return value[:index], value[index:]
+}
@@ -0,0 +1,114 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/check/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+//go:generate mockery --dir=../../ -r --name Executor
+
+var (
+	diskCheck = types.CheckDefinition{
+		Name:    "disk",
+		Command: []string{"/usr/lib/nagios/plugins/check_disk", "-w", "20%", "-c", "10%"},
+		Timeout: 5,
+	}
+)
+
+func TestCheck_Run_InterpretsExitCodes(t *testing.T) {
+	statuses := map[int]string{
+		0:  consts.CHECK_STATUS_OK,
+		1:  consts.CHECK_STATUS_WARNING,
+		2:  consts.CHECK_STATUS_CRITICAL,
+		3:  consts.CHECK_STATUS_UNKNOWN,
+		42: consts.CHECK_STATUS_UNKNOWN,
+	}
+
+	for exitCode, status := range statuses {
+		wrapper := new(mocks.Executor)
+		wrapper.On("Execute", mock.Anything, "/usr/lib/nagios/plugins/check_disk", "-w", "20%", "-c", "10%").
+			Return([]byte("DISK OK"), exitCode, nil)
+
+		executor = wrapper
+
+		result := Run(diskCheck)
+
+		assert.Equal(t, status, result.Status)
+		assert.Equal(t, exitCode, result.ExitCode)
+		assert.Equal(t, "disk", result.Name)
+		assert.Equal(t, "DISK OK", result.Output)
+		assert.NotZero(t, result.CheckTime)
+		wrapper.AssertExpectations(t)
+	}
+}
+
+func TestCheck_Run_ParsesPerfdata(t *testing.T) {
+	wrapper := new(mocks.Executor)
+	wrapper.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte("DISK WARNING - free space: / 3326 MB (56%) | /=2643MB;5948;5958;0;5968 'inode usage'=34%;80;90\n"), 1, nil)
+
+	executor = wrapper
+
+	result := Run(diskCheck)
+
+	assert.Equal(t, "DISK WARNING - free space: / 3326 MB (56%)", result.Output)
+	assert.Equal(t, []types.Perfdata{
+		{Label: "/", Value: 2643, UOM: "MB", Warning: "5948", Critical: "5958", Min: "0", Max: "5968"},
+		{Label: "inode usage", Value: 34, UOM: "%", Warning: "80", Critical: "90"},
+	}, result.Perfdata)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCheck_Run_ParsesMultilinePerfdata(t *testing.T) {
+	wrapper := new(mocks.Executor)
+	wrapper.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]byte("DISK OK | /=2643MB\n/ 15272 MB (77%);\n/boot 68 MB (69%); | /boot=68MB\n/home=69357MB"), 0, nil)
+
+	executor = wrapper
+
+	result := Run(diskCheck)
+
+	assert.Equal(t, "DISK OK\n/ 15272 MB (77%);\n/boot 68 MB (69%);", result.Output)
+	assert.Equal(t, 3, len(result.Perfdata))
+	assert.Equal(t, "/boot", result.Perfdata[1].Label)
+	assert.Equal(t, "/home", result.Perfdata[2].Label)
+	assert.Equal(t, 69357.0, result.Perfdata[2].Value)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCheck_Run_HandlesTimeout(t *testing.T) {
+	wrapper := new(mocks.Executor)
+	wrapper.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil, -1, context.DeadlineExceeded)
+
+	executor = wrapper
+
+	result := Run(types.CheckDefinition{Name: "slow", Command: diskCheck.Command, Timeout: 1})
+
+	assert.Equal(t, consts.CHECK_STATUS_UNKNOWN, result.Status)
+	assert.Equal(t, "check timed out after 1 seconds", result.Output)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCheck_Run_HandlesError(t *testing.T) {
+	wrapper := new(mocks.Executor)
+	wrapper.On("Execute", mock.Anything, "/missing").Return(nil, -1, fmt.Errorf("executable file not found"))
+
+	executor = wrapper
+
+	result := Run(types.CheckDefinition{Name: "missing", Command: []string{"/missing"}})
+
+	assert.Equal(t, consts.CHECK_STATUS_UNKNOWN, result.Status)
+	assert.Equal(t, "executable file not found", result.Output)
+	wrapper.AssertExpectations(t)
+}
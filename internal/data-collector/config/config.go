@@ -0,0 +1,36 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+var (
+	osWrapper wrapper.OperatingSystem = &wrapper.DefaultOS{}
+)
+
+// Get returns the agent configuration from the agent config file. A missing file
+// results in an empty configuration
+func Get() types.AgentConfig {
+	config := types.AgentConfig{}
+	fileName := utils.GetVariable(consts.AGENT_CONFIG_FILE)
+
+	data, err := osWrapper.ReadFile(fileName)
+	if err != nil {
+		if !osWrapper.IsNotExist(err) {
+			logger.Instance().Errorf("failed to read agent config: %s (%s)", fileName, err.Error())
+		}
+		return config
+	}
+
+	if err = json.Unmarshal(data, &config); err != nil {
+		logger.Instance().Errorf("failed to parse agent config: %s (%s)", fileName, err.Error())
+		return types.AgentConfig{}
+	}
+	return config
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/config/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name OperatingSystem
+
+func TestConfig_Get_ReturnsExpectedConfig(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "agent-config.json").Return([]byte(`{
+		"checks": [{"name": "disk", "command": ["/usr/lib/nagios/plugins/check_disk", "-w", "20%"], "interval": 60, "timeout": 10}]
+	}`), nil)
+
+	osWrapper = wrapper
+
+	config := Get()
+
+	assert.Equal(t, 1, len(config.Checks))
+	assert.Equal(t, "disk", config.Checks[0].Name)
+	assert.Equal(t, []string{"/usr/lib/nagios/plugins/check_disk", "-w", "20%"}, config.Checks[0].Command)
+	assert.Equal(t, 60, config.Checks[0].Interval)
+	assert.Equal(t, 10, config.Checks[0].Timeout)
+	wrapper.AssertExpectations(t)
+}
+
+func TestConfig_Get_ReadsConfiguredFile(t *testing.T) {
+	os.Clearenv()
+	os.Setenv(consts.AGENT_CONFIG_FILE, "/etc/shm/agent.json")
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "/etc/shm/agent.json").Return([]byte(`{}`), nil)
+
+	osWrapper = wrapper
+
+	config := Get()
+
+	assert.Equal(t, types.AgentConfig{}, config)
+	wrapper.AssertExpectations(t)
+	os.Clearenv()
+}
+
+func TestConfig_Get_HandlesMissingFile(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "agent-config.json").Return(nil, os.ErrNotExist)
+	wrapper.On("IsNotExist", os.ErrNotExist).Return(true)
+
+	osWrapper = wrapper
+
+	config := Get()
+
+	assert.Equal(t, types.AgentConfig{}, config)
+	wrapper.AssertExpectations(t)
+}
+
+func TestConfig_Get_HandlesInvalidFile(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "agent-config.json").Return([]byte(`{"checks": {}}`), nil)
+
+	osWrapper = wrapper
+
+	config := Get()
+
+	assert.Equal(t, types.AgentConfig{}, config)
+	wrapper.AssertExpectations(t)
+}
+
+func TestConfig_Get_HandlesReadError(t *testing.T) {
+	os.Clearenv()
+	err := fmt.Errorf("permission denied")
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("ReadFile", "agent-config.json").Return(nil, err)
+	wrapper.On("IsNotExist", err).Return(false)
+
+	osWrapper = wrapper
+
+	config := Get()
+
+	assert.Equal(t, types.AgentConfig{}, config)
+	wrapper.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+)
+
+// Settings contains the collection settings which the remote configuration can change while running
+type Settings struct {
+	defaultInterval time.Duration
+	interval        time.Duration
+	collectors      []string
+	lock            sync.RWMutex
+}
+
+// NewSettings returns settings which collect with the default collectors every interval
+func NewSettings(interval time.Duration) *Settings {
+	return &Settings{
+		defaultInterval: interval,
+		interval:        interval,
+	}
+}
+
+// Apply replaces the settings with those of a remote configuration. Unset fields fall back to the defaults
+func (s *Settings) Apply(remote types.RemoteConfig) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.interval = s.defaultInterval
+	if remote.Interval > 0 {
+		s.interval = time.Second * time.Duration(remote.Interval)
+	}
+	s.collectors = remote.Collectors
+}
+
+// Interval returns the delay between collecting health data
+func (s *Settings) Interval() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.interval
+}
+
+// IsEnabled returns if a collector should run. Without a remote list of collectors enabledByDefault is returned
+func (s *Settings) IsEnabled(collector string, enabledByDefault bool) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.collectors == nil {
+		return enabledByDefault
+	}
+	for _, name := range s.collectors {
+		if name == collector {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns the local agent configuration with the checks and probes of a remote configuration
+// added, replacing local definitions with the same name
+func Merge(local types.AgentConfig, remote types.RemoteConfig) types.AgentConfig {
+	merged := local
+	merged.Checks = utils.MergeChecks(local.Checks, remote.Checks)
+	merged.Probes = utils.MergeProbes(local.Probes, remote.Probes)
+	return merged
+}
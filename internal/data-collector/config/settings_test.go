@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_Apply_ReplacesIntervalAndCollectors(t *testing.T) {
+	settings := NewSettings(time.Second * 30)
+
+	assert.Equal(t, time.Second*30, settings.Interval())
+	assert.True(t, settings.IsEnabled("cpu", true))
+	assert.False(t, settings.IsEnabled("process", false))
+
+	settings.Apply(types.RemoteConfig{Interval: 10, Collectors: []string{"process"}})
+
+	assert.Equal(t, time.Second*10, settings.Interval())
+	assert.False(t, settings.IsEnabled("cpu", true))
+	assert.True(t, settings.IsEnabled("process", false))
+
+	settings.Apply(types.RemoteConfig{})
+
+	assert.Equal(t, time.Second*30, settings.Interval())
+	assert.True(t, settings.IsEnabled("cpu", true))
+}
+
+func TestSettings_Merge_AddsRemoteChecksAndProbes(t *testing.T) {
+	local := types.AgentConfig{
+		Checks:       []types.CheckDefinition{{Name: "disk", Interval: 60}},
+		Certificates: []types.CertificateDefinition{{File: "/etc/ssl/a.crt"}},
+	}
+
+	merged := Merge(local, types.RemoteConfig{
+		Checks: []types.CheckDefinition{{Name: "disk", Interval: 10}},
+		Probes: []types.ProbeDefinition{{Name: "nginx"}},
+	})
+
+	assert.Equal(t, []types.CheckDefinition{{Name: "disk", Interval: 10}}, merged.Checks)
+	assert.Equal(t, []types.ProbeDefinition{{Name: "nginx"}}, merged.Probes)
+	assert.Equal(t, local.Certificates, merged.Certificates)
+	assert.Equal(t, 60, local.Checks[0].Interval)
+}
@@ -0,0 +1,72 @@
+package cpu
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+var (
+	cpuWrapper wrapper.CPUInformation = &wrapper.GopsCPU{}
+
+	// The previous samples are kept so utilization can be calculated between intervals.
+	// On the first call these are empty, which results in the average since boot
+	lastTotal cpu.TimesStat
+	lastCores = map[string]cpu.TimesStat{}
+)
+
+// GetUtilization returns the cpu utilization for the host and each core since the last call
+func GetUtilization() types.CPU {
+	usage := types.CPU{}
+
+	total, err := cpuWrapper.Times(false)
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return usage
+	}
+	if len(total) >= 1 {
+		usage.Total = calculateUtilization(lastTotal, total[0])
+		lastTotal = total[0]
+	}
+
+	cores, err := cpuWrapper.Times(true)
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return usage
+	}
+	usage.Cores = make([]types.CPUUtilization, 0, len(cores))
+	for _, core := range cores {
+		usage.Cores = append(usage.Cores, calculateUtilization(lastCores[core.CPU], core))
+		lastCores[core.CPU] = core
+	}
+
+	return usage
+}
+
+// calculateUtilization returns the percentage of time spent in each state between two samples
+func calculateUtilization(previous, current cpu.TimesStat) types.CPUUtilization {
+	utilization := types.CPUUtilization{
+		CPU: current.CPU,
+	}
+
+	delta := current.Total() - previous.Total()
+	if delta <= 0 {
+		return utilization
+	}
+
+	percent := func(previous, current float64) float64 {
+		if current <= previous {
+			return 0
+		}
+		return (current - previous) / delta * 100
+	}
+
+	utilization.User = percent(previous.User, current.User)
+	utilization.System = percent(previous.System, current.System)
+	utilization.Iowait = percent(previous.Iowait, current.Iowait)
+	utilization.Steal = percent(previous.Steal, current.Steal)
+	utilization.Usage = 100 - percent(previous.Idle, current.Idle)
+	return utilization
+}
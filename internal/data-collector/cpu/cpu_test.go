@@ -0,0 +1,87 @@
+package cpu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/cpu/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name CPUInformation
+
+func resetCPU() {
+	lastTotal = cpu.TimesStat{}
+	lastCores = map[string]cpu.TimesStat{}
+}
+
+func TestCPU_GetUtilization_ReturnsUtilizationBetweenSamples(t *testing.T) {
+	resetCPU()
+	wrapper := new(mocks.CPUInformation)
+	wrapper.On("Times", false).Return([]cpu.TimesStat{
+		{CPU: "cpu-total", User: 10, System: 10, Idle: 80},
+	}, nil).Once()
+	wrapper.On("Times", true).Return([]cpu.TimesStat{
+		{CPU: "cpu0", User: 10, System: 10, Idle: 80},
+	}, nil).Once()
+	wrapper.On("Times", false).Return([]cpu.TimesStat{
+		{CPU: "cpu-total", User: 60, System: 20, Idle: 100, Iowait: 10, Steal: 10},
+	}, nil).Once()
+	wrapper.On("Times", true).Return([]cpu.TimesStat{
+		{CPU: "cpu0", User: 60, System: 20, Idle: 100, Iowait: 10, Steal: 10},
+	}, nil).Once()
+
+	cpuWrapper = wrapper
+
+	first := GetUtilization()
+	assert.Equal(t, 20.0, first.Total.Usage)
+
+	second := GetUtilization()
+	expected := types.CPUUtilization{
+		CPU:    "cpu-total",
+		Usage:  80,
+		User:   50,
+		System: 10,
+		Iowait: 10,
+		Steal:  10,
+	}
+	assert.Equal(t, expected, second.Total)
+	assert.Equal(t, 1, len(second.Cores))
+	assert.Equal(t, "cpu0", second.Cores[0].CPU)
+	assert.Equal(t, 80.0, second.Cores[0].Usage)
+	wrapper.AssertExpectations(t)
+}
+
+func TestCPU_GetUtilization_ReturnsZeroWhenNoTimeHasPassed(t *testing.T) {
+	resetCPU()
+	sample := []cpu.TimesStat{
+		{CPU: "cpu-total", User: 10, System: 10, Idle: 80},
+	}
+	wrapper := new(mocks.CPUInformation)
+	wrapper.On("Times", false).Return(sample, nil)
+	wrapper.On("Times", true).Return([]cpu.TimesStat{}, nil)
+
+	cpuWrapper = wrapper
+
+	GetUtilization()
+	usage := GetUtilization()
+
+	assert.Equal(t, types.CPUUtilization{CPU: "cpu-total"}, usage.Total)
+	assert.Equal(t, 0, len(usage.Cores))
+	wrapper.AssertExpectations(t)
+}
+
+func TestCPU_GetUtilization_HandlesError(t *testing.T) {
+	resetCPU()
+	wrapper := new(mocks.CPUInformation)
+	wrapper.On("Times", false).Return(nil, fmt.Errorf("platform not supported"))
+
+	cpuWrapper = wrapper
+
+	usage := GetUtilization()
+
+	assert.Equal(t, types.CPU{}, usage)
+	wrapper.AssertExpectations(t)
+}
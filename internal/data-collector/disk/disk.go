@@ -0,0 +1,91 @@
+package disk
+
+import (
+	"path"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+var (
+	diskWrapper wrapper.DiskInformation = &wrapper.GopsDisk{}
+)
+
+// GetUsage returns the usage of every mounted filesystem allowed by the fstype filters
+func GetUsage() []types.Disk {
+	log := logger.Instance()
+	disks := []types.Disk{}
+
+	partitions, err := diskWrapper.Partitions(true)
+	if err != nil {
+		log.Error(err.Error())
+		return disks
+	}
+
+	include := splitPatterns(utils.GetVariable(consts.DISK_INCLUDE_FSTYPES))
+	exclude := splitPatterns(utils.GetVariable(consts.DISK_EXCLUDE_FSTYPES))
+	seen := map[string]bool{}
+
+	for _, partition := range partitions {
+		if seen[partition.Mountpoint] || !isFstypeAllowed(partition.Fstype, include, exclude) {
+			continue
+		}
+		seen[partition.Mountpoint] = true
+
+		usage, err := diskWrapper.Usage(partition.Mountpoint)
+		if err != nil {
+			log.Warningf("failed to get disk usage for %s (%s)", partition.Mountpoint, err.Error())
+			continue
+		}
+
+		disks = append(disks, types.Disk{
+			Device:            partition.Device,
+			Mountpoint:        partition.Mountpoint,
+			Fstype:            partition.Fstype,
+			Total:             usage.Total,
+			Used:              usage.Used,
+			Free:              usage.Free,
+			UsedPercent:       usage.UsedPercent,
+			InodesTotal:       usage.InodesTotal,
+			InodesUsed:        usage.InodesUsed,
+			InodesFree:        usage.InodesFree,
+			InodesUsedPercent: usage.InodesUsedPercent,
+		})
+	}
+
+	return disks
+}
+
+// isFstypeAllowed checks a filesystem type against the include and exclude patterns.
+// An empty include list allows every filesystem type which is not excluded
+func isFstypeAllowed(fstype string, include []string, exclude []string) bool {
+	if matchesAny(fstype, exclude) {
+		return false
+	}
+	return len(include) == 0 || matchesAny(fstype, include)
+}
+
+// matchesAny returns true if the value matches at least one of the glob patterns
+func matchesAny(value string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPatterns splits a comma separated list of patterns
+func splitPatterns(value string) []string {
+	patterns := []string{}
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,96 @@
+package disk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/disk/mocks"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name DiskInformation
+
+func TestDisk_GetUsage_ReturnsUsageForAllowedFilesystems(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("Partitions", true).Return([]disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "tmpfs", Mountpoint: "/run", Fstype: "tmpfs"},
+		{Device: "/dev/sda2", Mountpoint: "/var", Fstype: "xfs"},
+		{Device: "/dev/sda2", Mountpoint: "/var", Fstype: "xfs"},
+	}, nil)
+	wrapper.On("Usage", "/").Return(&disk.UsageStat{Total: 100, Used: 40, Free: 60, UsedPercent: 40, InodesTotal: 10, InodesUsed: 1, InodesFree: 9}, nil)
+	wrapper.On("Usage", "/var").Return(&disk.UsageStat{Total: 100, Used: 99, Free: 1, UsedPercent: 99}, nil).Once()
+
+	diskWrapper = wrapper
+
+	disks := GetUsage()
+
+	assert.Equal(t, 2, len(disks))
+	assert.Equal(t, "/", disks[0].Mountpoint)
+	assert.Equal(t, "ext4", disks[0].Fstype)
+	assert.Equal(t, uint64(40), disks[0].Used)
+	assert.Equal(t, uint64(1), disks[0].InodesUsed)
+	assert.Equal(t, "/var", disks[1].Mountpoint)
+	assert.Equal(t, 99.0, disks[1].UsedPercent)
+	wrapper.AssertExpectations(t)
+}
+
+func TestDisk_GetUsage_UsesIncludeAndExcludePatterns(t *testing.T) {
+	os.Clearenv()
+	os.Setenv(consts.DISK_INCLUDE_FSTYPES, "ext*,tmpfs")
+	os.Setenv(consts.DISK_EXCLUDE_FSTYPES, "ext2")
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("Partitions", true).Return([]disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "/dev/sda2", Mountpoint: "/boot", Fstype: "ext2"},
+		{Device: "tmpfs", Mountpoint: "/run", Fstype: "tmpfs"},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Fstype: "xfs"},
+	}, nil)
+	wrapper.On("Usage", "/").Return(&disk.UsageStat{Total: 100}, nil)
+	wrapper.On("Usage", "/run").Return(&disk.UsageStat{Total: 10}, nil)
+
+	diskWrapper = wrapper
+
+	disks := GetUsage()
+
+	assert.Equal(t, 2, len(disks))
+	assert.Equal(t, "/", disks[0].Mountpoint)
+	assert.Equal(t, "/run", disks[1].Mountpoint)
+	wrapper.AssertExpectations(t)
+	os.Clearenv()
+}
+
+func TestDisk_GetUsage_SkipsFailedMountpoints(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("Partitions", true).Return([]disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+		{Device: "server:/export", Mountpoint: "/mnt/nfs", Fstype: "nfs"},
+	}, nil)
+	wrapper.On("Usage", "/").Return(&disk.UsageStat{Total: 100}, nil)
+	wrapper.On("Usage", "/mnt/nfs").Return(nil, fmt.Errorf("stale file handle"))
+
+	diskWrapper = wrapper
+
+	disks := GetUsage()
+
+	assert.Equal(t, 1, len(disks))
+	assert.Equal(t, "/", disks[0].Mountpoint)
+	wrapper.AssertExpectations(t)
+}
+
+func TestDisk_GetUsage_HandlesError(t *testing.T) {
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("Partitions", true).Return(nil, fmt.Errorf("platform not supported"))
+
+	diskWrapper = wrapper
+
+	disks := GetUsage()
+
+	assert.Equal(t, 0, len(disks))
+	wrapper.AssertExpectations(t)
+}
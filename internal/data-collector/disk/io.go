@@ -0,0 +1,59 @@
+package disk
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+var (
+	now = time.Now
+
+	// The previous sample is kept so rates can be calculated between intervals
+	lastIOCounters map[string]disk.IOCountersStat
+	lastIOTime     time.Time
+)
+
+// GetIORates returns the per second io rates of each block device since the last call.
+// Devices which were not present in the previous sample are only reported from the next call
+func GetIORates() []types.DiskIO {
+	rates := []types.DiskIO{}
+
+	counters, err := diskWrapper.IOCounters()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return rates
+	}
+
+	sampleTime := now()
+	elapsed := sampleTime.Sub(lastIOTime).Seconds()
+
+	for name, current := range counters {
+		previous, ok := lastIOCounters[name]
+		if !ok || elapsed <= 0 {
+			continue
+		}
+
+		rates = append(rates, types.DiskIO{
+			Device:              name,
+			ReadBytesPerSecond:  float64(utils.GetCounterDelta(previous.ReadBytes, current.ReadBytes)) / elapsed,
+			WriteBytesPerSecond: float64(utils.GetCounterDelta(previous.WriteBytes, current.WriteBytes)) / elapsed,
+			ReadsPerSecond:      float64(utils.GetCounterDelta(previous.ReadCount, current.ReadCount)) / elapsed,
+			WritesPerSecond:     float64(utils.GetCounterDelta(previous.WriteCount, current.WriteCount)) / elapsed,
+		})
+	}
+
+	// Devices which disappeared are dropped by replacing the whole sample
+	lastIOCounters = counters
+	lastIOTime = sampleTime
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Device < rates[j].Device
+	})
+	return rates
+}
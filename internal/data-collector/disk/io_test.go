@@ -0,0 +1,89 @@
+package disk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/disk/mocks"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetIO(sampleTimes ...time.Time) {
+	lastIOCounters = nil
+	lastIOTime = time.Time{}
+	now = func() time.Time {
+		sampleTime := sampleTimes[0]
+		sampleTimes = sampleTimes[1:]
+		return sampleTime
+	}
+}
+
+func TestDisk_GetIORates_ReturnsRatesBetweenSamples(t *testing.T) {
+	start := time.Now()
+	resetIO(start, start.Add(time.Second*10))
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("IOCounters").Return(map[string]disk.IOCountersStat{
+		"sda": {Name: "sda", ReadBytes: 1000, WriteBytes: 2000, ReadCount: 10, WriteCount: 20},
+	}, nil).Once()
+	wrapper.On("IOCounters").Return(map[string]disk.IOCountersStat{
+		"sda": {Name: "sda", ReadBytes: 11000, WriteBytes: 4000, ReadCount: 110, WriteCount: 70},
+		"sdb": {Name: "sdb", ReadBytes: 500},
+	}, nil).Once()
+
+	diskWrapper = wrapper
+
+	first := GetIORates()
+	second := GetIORates()
+
+	assert.Equal(t, 0, len(first))
+	assert.Equal(t, 1, len(second))
+	assert.Equal(t, "sda", second[0].Device)
+	assert.Equal(t, 1000.0, second[0].ReadBytesPerSecond)
+	assert.Equal(t, 200.0, second[0].WriteBytesPerSecond)
+	assert.Equal(t, 10.0, second[0].ReadsPerSecond)
+	assert.Equal(t, 5.0, second[0].WritesPerSecond)
+	wrapper.AssertExpectations(t)
+}
+
+func TestDisk_GetIORates_HandlesRemovedDevices(t *testing.T) {
+	start := time.Now()
+	resetIO(start, start.Add(time.Second), start.Add(time.Second*2))
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("IOCounters").Return(map[string]disk.IOCountersStat{
+		"sda": {Name: "sda"},
+		"sdb": {Name: "sdb"},
+	}, nil).Once()
+	wrapper.On("IOCounters").Return(map[string]disk.IOCountersStat{
+		"sda": {Name: "sda"},
+	}, nil).Once()
+	wrapper.On("IOCounters").Return(map[string]disk.IOCountersStat{
+		"sda": {Name: "sda"},
+		"sdb": {Name: "sdb", ReadBytes: 100},
+	}, nil).Once()
+
+	diskWrapper = wrapper
+
+	GetIORates()
+	second := GetIORates()
+	third := GetIORates()
+
+	assert.Equal(t, 1, len(second))
+	assert.Equal(t, 1, len(third))
+	assert.Equal(t, "sda", third[0].Device)
+	wrapper.AssertExpectations(t)
+}
+
+func TestDisk_GetIORates_HandlesError(t *testing.T) {
+	resetIO()
+	wrapper := new(mocks.DiskInformation)
+	wrapper.On("IOCounters").Return(nil, fmt.Errorf("platform not supported"))
+
+	diskWrapper = wrapper
+
+	rates := GetIORates()
+
+	assert.Equal(t, 0, len(rates))
+	wrapper.AssertExpectations(t)
+}
@@ -1,8 +1,12 @@
 package host
 
 import (
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
 	"github.com/PR-Developers/server-health-monitor/internal/logger"
 	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
 	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
 )
 
@@ -19,3 +23,29 @@ func GetInfo() *types.Host {
 	}
 	return host
 }
+
+// Watch collects the host information every interval, starting after the first interval, and passes it to
+// send when its inventory differs from sent, the information which was sent last. Send returns if the
+// information was accepted, otherwise it is sent again on the next interval. Calling the returned function
+// stops watching
+func Watch(sent *types.Host, interval time.Duration, send func(host *types.Host) bool) func() {
+	return scheduler.After(interval, func() {
+		sent = refresh(sent, send)
+	})
+}
+
+// refresh collects the host information and sends it when the inventory changed, returning the
+// information which was sent last
+func refresh(sent *types.Host, send func(host *types.Host) bool) *types.Host {
+	current := GetInfo()
+	if current == nil {
+		return sent
+	}
+	if sent != nil && len(utils.DiffHosts(sent, current)) == 0 {
+		return sent
+	}
+	if !send(current) {
+		return sent
+	}
+	return current
+}
@@ -39,3 +39,42 @@ func TestHost_GetInfo_HandlesError(t *testing.T) {
 	assert.Nil(t, host)
 	wrapper.AssertExpectations(t)
 }
+
+func TestHost_Refresh_SendsChangedInventory(t *testing.T) {
+	wrapper := new(mocks.HostInformation)
+	current := &types.Host{Hostname: "test", KernelVersion: "5.10.0-2", Uptime: 20}
+	wrapper.On("Info").Return(current, nil)
+	hostWrapper = wrapper
+	sent := []*types.Host{}
+
+	result := refresh(&types.Host{Hostname: "test", KernelVersion: "5.10.0-1", Uptime: 10}, func(host *types.Host) bool {
+		sent = append(sent, host)
+		return true
+	})
+
+	assert.Equal(t, current, result)
+	assert.Equal(t, []*types.Host{current}, sent)
+}
+
+func TestHost_Refresh_SkipsUnchangedInventory(t *testing.T) {
+	wrapper := new(mocks.HostInformation)
+	wrapper.On("Info").Return(&types.Host{Hostname: "test", Uptime: 20}, nil)
+	hostWrapper = wrapper
+	previous := &types.Host{Hostname: "test", Uptime: 10}
+
+	result := refresh(previous, func(host *types.Host) bool {
+		t.Error("unchanged host information was sent")
+		return true
+	})
+
+	assert.Equal(t, previous, result)
+}
+
+func TestHost_Refresh_KeepsPreviousWhenSendFails(t *testing.T) {
+	wrapper := new(mocks.HostInformation)
+	wrapper.On("Info").Return(&types.Host{Hostname: "test"}, nil)
+	hostWrapper = wrapper
+
+	assert.Nil(t, refresh(nil, func(host *types.Host) bool { return false }))
+	assert.Equal(t, &types.Host{Hostname: "test"}, refresh(nil, func(host *types.Host) bool { return true }))
+}
@@ -0,0 +1,39 @@
+package load
+
+import (
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+var (
+	loadWrapper wrapper.LoadInformation = &wrapper.GopsLoad{}
+	now                                 = time.Now
+
+	// The previous sample is kept so the context switch rate can be calculated between intervals
+	lastContextSwitches uint64
+	lastTime            time.Time
+)
+
+// GetInfo returns the load averages, process counts and context switches of the host
+func GetInfo() types.Load {
+	load, err := loadWrapper.Info()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return types.Load{}
+	}
+
+	sampleTime := now()
+	if !lastTime.IsZero() {
+		if elapsed := sampleTime.Sub(lastTime).Seconds(); elapsed > 0 {
+			load.ContextSwitchesPerSecond = float64(utils.GetCounterDelta(lastContextSwitches, load.ContextSwitches)) / elapsed
+		}
+	}
+	lastContextSwitches = load.ContextSwitches
+	lastTime = sampleTime
+
+	return *load
+}
@@ -0,0 +1,75 @@
+package load
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/load/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name LoadInformation
+
+func resetLoad(sampleTimes ...time.Time) {
+	lastContextSwitches = 0
+	lastTime = time.Time{}
+	now = func() time.Time {
+		sampleTime := sampleTimes[0]
+		sampleTimes = sampleTimes[1:]
+		return sampleTime
+	}
+}
+
+func TestLoad_GetInfo_ReturnsExpectedLoadInformation(t *testing.T) {
+	resetLoad(time.Now())
+	wrapper := new(mocks.LoadInformation)
+	loadInfo := &types.Load{
+		Load1:           1.5,
+		Load5:           1.0,
+		Load15:          0.5,
+		ProcsTotal:      200,
+		ProcsRunning:    2,
+		ProcsBlocked:    1,
+		ContextSwitches: 1000,
+	}
+	wrapper.On("Info").Return(loadInfo, nil)
+
+	loadWrapper = wrapper
+
+	load := GetInfo()
+
+	assert.Equal(t, *loadInfo, load)
+	assert.Equal(t, 0.0, load.ContextSwitchesPerSecond)
+	wrapper.AssertExpectations(t)
+}
+
+func TestLoad_GetInfo_ReturnsContextSwitchRate(t *testing.T) {
+	start := time.Now()
+	resetLoad(start, start.Add(time.Second*10))
+	wrapper := new(mocks.LoadInformation)
+	wrapper.On("Info").Return(&types.Load{ContextSwitches: 1000}, nil).Once()
+	wrapper.On("Info").Return(&types.Load{ContextSwitches: 6000}, nil).Once()
+
+	loadWrapper = wrapper
+
+	GetInfo()
+	load := GetInfo()
+
+	assert.Equal(t, 500.0, load.ContextSwitchesPerSecond)
+	wrapper.AssertExpectations(t)
+}
+
+func TestLoad_GetInfo_HandlesError(t *testing.T) {
+	resetLoad()
+	wrapper := new(mocks.LoadInformation)
+	wrapper.On("Info").Return(nil, fmt.Errorf("platform not supported"))
+
+	loadWrapper = wrapper
+
+	load := GetInfo()
+
+	assert.Equal(t, types.Load{}, load)
+	wrapper.AssertExpectations(t)
+}
@@ -0,0 +1,193 @@
+package logwatch
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+const (
+	defaultInterval = 10
+	maxSamples      = 5
+	maxLineLength   = 256
+	maxReadBytes    = 1024 * 1024
+)
+
+var (
+	osWrapper wrapper.OperatingSystem = &wrapper.DefaultOS{}
+	now                               = time.Now
+)
+
+type rule struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+// watcher follows a single log file between polls
+type watcher struct {
+	file    string
+	rules   []rule
+	info    os.FileInfo
+	offset  int64
+	partial string
+	started bool
+}
+
+// Start follows every log file on its own interval in the background and passes the events to send.
+// Calling the returned function stops all of them
+func Start(definitions []types.LogDefinition, send func(event types.Event)) func() {
+	stops := []func(){}
+	for _, definition := range definitions {
+		w := newWatcher(definition)
+		if w == nil {
+			continue
+		}
+
+		interval := definition.Interval
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+		stops = append(stops, scheduler.Every(time.Second*time.Duration(interval), func() {
+			for _, event := range w.poll() {
+				send(event)
+			}
+		}))
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// newWatcher returns a watcher for a log definition or nil if the definition has nothing to watch
+func newWatcher(definition types.LogDefinition) *watcher {
+	if definition.File == "" {
+		logger.Instance().Warning("skipping log as it has no file")
+		return nil
+	}
+
+	rules := []rule{}
+	for _, r := range definition.Rules {
+		regex, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			logger.Instance().Errorf("skipping log rule %s for %s as the pattern is invalid (%s)", r.Name, definition.File, err.Error())
+			continue
+		}
+		rules = append(rules, rule{name: r.Name, regex: regex})
+	}
+	if len(rules) == 0 {
+		logger.Instance().Warningf("skipping log %s as it has no valid rules", definition.File)
+		return nil
+	}
+
+	return &watcher{
+		file:  definition.File,
+		rules: rules,
+	}
+}
+
+// poll reads the lines appended since the previous poll and returns an event for every rule
+// which matched at least one of them. The first poll starts at the end of the file so old
+// lines are not reported, a rotated or truncated file is read from the start
+func (w *watcher) poll() []types.Event {
+	info, err := osWrapper.Stat(w.file)
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		w.info = nil
+		w.started = true
+		return []types.Event{}
+	}
+
+	switch {
+	case !w.started:
+		w.offset = info.Size()
+	case w.info == nil, !os.SameFile(w.info, info), info.Size() < w.offset:
+		w.offset = 0
+		w.partial = ""
+	}
+	w.info = info
+	w.started = true
+
+	if info.Size() == w.offset {
+		return []types.Event{}
+	}
+
+	data, err := w.read(info.Size())
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return []types.Event{}
+	}
+
+	lines := strings.Split(w.partial+string(data), "\n")
+	// The last element is either empty or a line which is still being written
+	w.partial = lines[len(lines)-1]
+	if len(w.partial) > maxReadBytes {
+		w.partial = ""
+	}
+
+	return w.match(lines[:len(lines)-1])
+}
+
+// read returns the bytes between the current offset and size, skipping ahead if too much was written
+func (w *watcher) read(size int64) ([]byte, error) {
+	if size-w.offset > maxReadBytes {
+		logger.Instance().Warningf("skipping %d bytes of %s as too much was written since the last poll", size-w.offset-maxReadBytes, w.file)
+		w.offset = size - maxReadBytes
+		w.partial = ""
+	}
+
+	file, err := osWrapper.OpenFile(w.file, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, err = file.Seek(w.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(file, size-w.offset))
+	if err != nil {
+		return nil, err
+	}
+	w.offset += int64(len(data))
+	return data, nil
+}
+
+// match returns an event for every rule which matched at least one line
+func (w *watcher) match(lines []string) []types.Event {
+	events := []types.Event{}
+	eventTime := now().UTC().UnixNano()
+	for _, r := range w.rules {
+		event := types.Event{
+			EventTime: eventTime,
+			Source:    w.file,
+			Rule:      r.name,
+			Samples:   []string{},
+		}
+		for _, line := range lines {
+			if !r.regex.MatchString(line) {
+				continue
+			}
+			event.Count++
+			if len(event.Samples) < maxSamples {
+				if runes := []rune(line); len(runes) > maxLineLength {
+					line = string(runes[:maxLineLength])
+				}
+				event.Samples = append(event.Samples, line)
+			}
+		}
+		if event.Count > 0 {
+			events = append(events, event)
+		}
+	}
+	return events
+}
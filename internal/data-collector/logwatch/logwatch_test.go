@@ -0,0 +1,134 @@
+package logwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	syslogRules []types.LogRule = []types.LogRule{
+		{Name: "oom", Pattern: "Out of memory"},
+		{Name: "segfault", Pattern: "segfault"},
+	}
+)
+
+func appendToFile(t *testing.T, file string, data string) {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	assert.Nil(t, err)
+	_, err = f.WriteString(data)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+}
+
+func getInitializedWatcher(t *testing.T) (*watcher, string) {
+	file := filepath.Join(t.TempDir(), "syslog")
+	appendToFile(t, file, "kernel: Out of memory: Killed process 1 (old)\n")
+	now = func() time.Time { return time.Unix(100, 0) }
+
+	w := newWatcher(types.LogDefinition{File: file, Rules: syslogRules})
+	assert.Empty(t, w.poll())
+	return w, file
+}
+
+func TestLogwatch_Poll_ReturnsMatchesOfNewLines(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	appendToFile(t, file, "kernel: Out of memory: Killed process 2 (java)\nsshd: accepted\nkernel: Out of memory: Killed process 3 (java)\n")
+
+	events := w.poll()
+
+	assert.Equal(t, []types.Event{
+		{
+			EventTime: time.Unix(100, 0).UnixNano(),
+			Source:    file,
+			Rule:      "oom",
+			Count:     2,
+			Samples:   []string{"kernel: Out of memory: Killed process 2 (java)", "kernel: Out of memory: Killed process 3 (java)"},
+		},
+	}, events)
+	assert.Empty(t, w.poll())
+}
+
+func TestLogwatch_Poll_WaitsForPartialLines(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	appendToFile(t, file, "app[1]: segfa")
+
+	assert.Empty(t, w.poll())
+
+	appendToFile(t, file, "ult at 0 ip 0\n")
+	events := w.poll()
+
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "segfault", events[0].Rule)
+	assert.Equal(t, []string{"app[1]: segfault at 0 ip 0"}, events[0].Samples)
+}
+
+func TestLogwatch_Poll_LimitsSamples(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	appendToFile(t, file, strings.Repeat("app: segfault "+strings.Repeat("x", 300)+"\n", 10))
+
+	events := w.poll()
+
+	assert.Equal(t, 10, events[0].Count)
+	assert.Equal(t, maxSamples, len(events[0].Samples))
+	assert.Equal(t, maxLineLength, len(events[0].Samples[0]))
+}
+
+func TestLogwatch_Poll_TruncatesSamplesOnRuneBoundary(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	prefix := "app: segfault " + strings.Repeat("x", maxLineLength-15)
+	appendToFile(t, file, prefix+strings.Repeat("é", 3)+"\n")
+
+	events := w.poll()
+
+	assert.True(t, utf8.ValidString(events[0].Samples[0]))
+	assert.Equal(t, prefix+"é", events[0].Samples[0])
+}
+
+func TestLogwatch_Poll_HandlesTruncation(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	assert.Nil(t, os.WriteFile(file, []byte("app: segfault\n"), 0644))
+
+	events := w.poll()
+
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "segfault", events[0].Rule)
+}
+
+func TestLogwatch_Poll_HandlesRotation(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	assert.Nil(t, os.Rename(file, file+".1"))
+	appendToFile(t, file, "kernel: Out of memory: Killed process 4 (java)\n")
+
+	events := w.poll()
+
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "oom", events[0].Rule)
+	assert.Equal(t, 1, events[0].Count)
+}
+
+func TestLogwatch_Poll_HandlesMissingFile(t *testing.T) {
+	w, file := getInitializedWatcher(t)
+	assert.Nil(t, os.Remove(file))
+
+	assert.Empty(t, w.poll())
+
+	appendToFile(t, file, "app: segfault\n")
+	events := w.poll()
+
+	assert.Equal(t, 1, len(events))
+}
+
+func TestLogwatch_NewWatcher_SkipsInvalidDefinitions(t *testing.T) {
+	assert.Nil(t, newWatcher(types.LogDefinition{Rules: syslogRules}))
+	assert.Nil(t, newWatcher(types.LogDefinition{File: "syslog", Rules: []types.LogRule{{Name: "bad", Pattern: "("}}}))
+
+	w := newWatcher(types.LogDefinition{File: "syslog", Rules: append(syslogRules, types.LogRule{Name: "bad", Pattern: "("})})
+	assert.Equal(t, 2, len(w.rules))
+}
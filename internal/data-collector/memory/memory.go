@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+var (
+	memoryWrapper wrapper.MemoryInformation = &wrapper.GopsMemory{}
+)
+
+// GetInfo returns all memory and swap information
+func GetInfo() types.Memory {
+	memory, err := memoryWrapper.Info()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return types.Memory{}
+	}
+	return *memory
+}
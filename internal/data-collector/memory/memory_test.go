@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/memory/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name MemoryInformation
+
+func TestMemory_GetInfo_ReturnsExpectedMemoryInformation(t *testing.T) {
+	wrapper := new(mocks.MemoryInformation)
+	memoryInfo := &types.Memory{
+		Total:     8192,
+		Used:      4096,
+		Available: 2048,
+		Cached:    2048,
+		SwapTotal: 1024,
+		SwapUsed:  512,
+	}
+	wrapper.On("Info").Return(memoryInfo, nil)
+
+	memoryWrapper = wrapper
+
+	memory := GetInfo()
+
+	assert.Equal(t, *memoryInfo, memory)
+	wrapper.AssertExpectations(t)
+}
+
+func TestMemory_GetInfo_HandlesError(t *testing.T) {
+	wrapper := new(mocks.MemoryInformation)
+	wrapper.On("Info").Return(nil, fmt.Errorf("platform not supported"))
+
+	memoryWrapper = wrapper
+
+	memory := GetInfo()
+
+	assert.Equal(t, types.Memory{}, memory)
+	wrapper.AssertExpectations(t)
+}
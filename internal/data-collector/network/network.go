@@ -0,0 +1,68 @@
+package network
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+var (
+	networkWrapper wrapper.NetworkInformation = &wrapper.GopsNetwork{}
+	now                                       = time.Now
+
+	// The previous sample is kept so rates can be calculated between intervals
+	lastCounters map[string]net.IOCountersStat
+	lastTime     time.Time
+)
+
+// GetRates returns the per second throughput of each network interface since the last call.
+// Interfaces which were not present in the previous sample are only reported from the next call
+func GetRates() []types.NetworkIO {
+	rates := []types.NetworkIO{}
+
+	counters, err := networkWrapper.IOCounters()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return rates
+	}
+
+	sampleTime := now()
+	elapsed := sampleTime.Sub(lastTime).Seconds()
+	samples := make(map[string]net.IOCountersStat, len(counters))
+
+	for _, current := range counters {
+		samples[current.Name] = current
+
+		previous, ok := lastCounters[current.Name]
+		if !ok || elapsed <= 0 {
+			continue
+		}
+
+		rates = append(rates, types.NetworkIO{
+			Interface:          current.Name,
+			RxBytesPerSecond:   float64(utils.GetCounterDelta(previous.BytesRecv, current.BytesRecv)) / elapsed,
+			TxBytesPerSecond:   float64(utils.GetCounterDelta(previous.BytesSent, current.BytesSent)) / elapsed,
+			RxPacketsPerSecond: float64(utils.GetCounterDelta(previous.PacketsRecv, current.PacketsRecv)) / elapsed,
+			TxPacketsPerSecond: float64(utils.GetCounterDelta(previous.PacketsSent, current.PacketsSent)) / elapsed,
+			RxErrorsPerSecond:  float64(utils.GetCounterDelta(previous.Errin, current.Errin)) / elapsed,
+			TxErrorsPerSecond:  float64(utils.GetCounterDelta(previous.Errout, current.Errout)) / elapsed,
+			RxDropsPerSecond:   float64(utils.GetCounterDelta(previous.Dropin, current.Dropin)) / elapsed,
+			TxDropsPerSecond:   float64(utils.GetCounterDelta(previous.Dropout, current.Dropout)) / elapsed,
+		})
+	}
+
+	// Interfaces which disappeared are dropped by replacing the whole sample
+	lastCounters = samples
+	lastTime = sampleTime
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Interface < rates[j].Interface
+	})
+	return rates
+}
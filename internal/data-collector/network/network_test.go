@@ -0,0 +1,88 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/network/mocks"
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name NetworkInformation
+
+func resetNetwork(sampleTimes ...time.Time) {
+	lastCounters = nil
+	lastTime = time.Time{}
+	now = func() time.Time {
+		sampleTime := sampleTimes[0]
+		sampleTimes = sampleTimes[1:]
+		return sampleTime
+	}
+}
+
+func TestNetwork_GetRates_ReturnsRatesBetweenSamples(t *testing.T) {
+	start := time.Now()
+	resetNetwork(start, start.Add(time.Second*10))
+	wrapper := new(mocks.NetworkInformation)
+	wrapper.On("IOCounters").Return([]net.IOCountersStat{
+		{Name: "eth0", BytesRecv: 1000, BytesSent: 1000},
+	}, nil).Once()
+	wrapper.On("IOCounters").Return([]net.IOCountersStat{
+		{Name: "eth0", BytesRecv: 11000, BytesSent: 2000, PacketsRecv: 100, PacketsSent: 50, Errin: 10, Errout: 20, Dropin: 30, Dropout: 40},
+		{Name: "eth1", BytesRecv: 500},
+	}, nil).Once()
+
+	networkWrapper = wrapper
+
+	first := GetRates()
+	second := GetRates()
+
+	assert.Equal(t, 0, len(first))
+	assert.Equal(t, 1, len(second))
+	assert.Equal(t, "eth0", second[0].Interface)
+	assert.Equal(t, 1000.0, second[0].RxBytesPerSecond)
+	assert.Equal(t, 100.0, second[0].TxBytesPerSecond)
+	assert.Equal(t, 10.0, second[0].RxPacketsPerSecond)
+	assert.Equal(t, 5.0, second[0].TxPacketsPerSecond)
+	assert.Equal(t, 1.0, second[0].RxErrorsPerSecond)
+	assert.Equal(t, 2.0, second[0].TxErrorsPerSecond)
+	assert.Equal(t, 3.0, second[0].RxDropsPerSecond)
+	assert.Equal(t, 4.0, second[0].TxDropsPerSecond)
+	wrapper.AssertExpectations(t)
+}
+
+func TestNetwork_GetRates_HandlesWraparound(t *testing.T) {
+	start := time.Now()
+	resetNetwork(start, start.Add(time.Second))
+	wrapper := new(mocks.NetworkInformation)
+	wrapper.On("IOCounters").Return([]net.IOCountersStat{
+		{Name: "eth0", BytesRecv: math.MaxUint32 - 99},
+	}, nil).Once()
+	wrapper.On("IOCounters").Return([]net.IOCountersStat{
+		{Name: "eth0", BytesRecv: 100},
+	}, nil).Once()
+
+	networkWrapper = wrapper
+
+	GetRates()
+	rates := GetRates()
+
+	assert.Equal(t, 200.0, rates[0].RxBytesPerSecond)
+	wrapper.AssertExpectations(t)
+}
+
+func TestNetwork_GetRates_HandlesError(t *testing.T) {
+	resetNetwork()
+	wrapper := new(mocks.NetworkInformation)
+	wrapper.On("IOCounters").Return(nil, fmt.Errorf("platform not supported"))
+
+	networkWrapper = wrapper
+
+	rates := GetRates()
+
+	assert.Equal(t, 0, len(rates))
+	wrapper.AssertExpectations(t)
+}
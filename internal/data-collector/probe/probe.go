@@ -0,0 +1,146 @@
+package probe
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+const (
+	defaultInterval = 60
+	defaultTimeout  = 10
+	// maxBodySize is the maximum amount of bytes read from a response when matching the body
+	maxBodySize = 1024 * 1024
+)
+
+var (
+	// transports are shared by every http probe (keyed by InsecureSkipVerify) so idle connections are reused instead of leaked
+	transports = map[bool]*http.Transport{
+		false: {TLSClientConfig: &tls.Config{}},
+		true:  {TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+)
+
+// Start runs every probe on its own interval in the background and passes each result to send.
+// Calling the returned function stops all of them
+func Start(definitions []types.ProbeDefinition, send func(result types.ProbeResult)) func() {
+	stops := []func(){}
+	for _, definition := range definitions {
+		if definition.Type != consts.PROBE_TYPE_HTTP && definition.Type != consts.PROBE_TYPE_TCP {
+			logger.Instance().Warningf("skipping probe %s as it has an unknown type: %s", definition.Name, definition.Type)
+			continue
+		}
+
+		interval := definition.Interval
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+		definition := definition
+		stops = append(stops, scheduler.Every(time.Second*time.Duration(interval), func() {
+			send(Run(definition))
+		}))
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// Run performs a single probe
+func Run(definition types.ProbeDefinition) types.ProbeResult {
+	timeout := definition.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	result := types.ProbeResult{
+		Name:      definition.Name,
+		Type:      definition.Type,
+		Target:    definition.Target,
+		ProbeTime: time.Now().UTC().UnixNano(),
+	}
+
+	start := time.Now()
+	var err error
+	switch definition.Type {
+	case consts.PROBE_TYPE_HTTP:
+		result.StatusCode, err = probeHTTP(definition, time.Second*time.Duration(timeout))
+	case consts.PROBE_TYPE_TCP:
+		err = probeTCP(definition, time.Second*time.Duration(timeout))
+	default:
+		err = fmt.Errorf("unknown probe type: %s", definition.Type)
+	}
+	result.Latency = time.Since(start).Milliseconds()
+
+	if err == nil && definition.LatencyBudget > 0 && result.Latency > int64(definition.LatencyBudget) {
+		err = fmt.Errorf("latency of %dms is over the budget of %dms", result.Latency, definition.LatencyBudget)
+	}
+
+	result.Success = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// probeHTTP makes a GET request and validates the status code and body
+func probeHTTP(definition types.ProbeDefinition, timeout time.Duration) (int, error) {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transports[definition.InsecureSkipVerify],
+	}
+
+	response, err := client.Get(definition.Target)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// the body has to be read to the end for the connection to be reused
+		io.Copy(io.Discard, io.LimitReader(response.Body, maxBodySize))
+		response.Body.Close()
+	}()
+
+	expectedStatus := definition.ExpectedStatus
+	if expectedStatus == 0 {
+		expectedStatus = http.StatusOK
+	}
+	if response.StatusCode != expectedStatus {
+		return response.StatusCode, fmt.Errorf("expected status code %d but got %d", expectedStatus, response.StatusCode)
+	}
+
+	if definition.BodyRegex != "" {
+		pattern, err := regexp.Compile(definition.BodyRegex)
+		if err != nil {
+			return response.StatusCode, fmt.Errorf("invalid body regex: %s", err.Error())
+		}
+		body, err := io.ReadAll(io.LimitReader(response.Body, maxBodySize))
+		if err != nil {
+			return response.StatusCode, err
+		}
+		if !pattern.Match(body) {
+			return response.StatusCode, fmt.Errorf("body did not match %s", definition.BodyRegex)
+		}
+	}
+
+	return response.StatusCode, nil
+}
+
+// probeTCP opens and closes a TCP connection
+func probeTCP(definition types.ProbeDefinition, timeout time.Duration) error {
+	connection, err := net.DialTimeout("tcp", definition.Target, timeout)
+	if err != nil {
+		return err
+	}
+	return connection.Close()
+}
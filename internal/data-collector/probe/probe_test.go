@@ -0,0 +1,131 @@
+package probe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(status int, body string, delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestProbe_Run_HTTPSucceeds(t *testing.T) {
+	server := newTestServer(200, "<h1>Welcome to nginx!</h1>", 0)
+	defer server.Close()
+
+	result := Run(types.ProbeDefinition{
+		Name:      "nginx",
+		Type:      consts.PROBE_TYPE_HTTP,
+		Target:    server.URL,
+		BodyRegex: "Welcome to (nginx|apache)",
+	})
+
+	assert.True(t, result.Success)
+	assert.Equal(t, "nginx", result.Name)
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, "", result.Error)
+	assert.NotZero(t, result.ProbeTime)
+}
+
+func TestProbe_Run_HTTPReusesConnections(t *testing.T) {
+	connections := int32(0)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	definition := types.ProbeDefinition{Name: "nginx", Type: consts.PROBE_TYPE_HTTP, Target: server.URL}
+	assert.True(t, Run(definition).Success)
+	assert.True(t, Run(definition).Success)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+}
+
+func TestProbe_Run_HTTPFailsOnUnexpectedStatus(t *testing.T) {
+	server := newTestServer(502, "Bad Gateway", 0)
+	defer server.Close()
+
+	result := Run(types.ProbeDefinition{Type: consts.PROBE_TYPE_HTTP, Target: server.URL})
+
+	assert.False(t, result.Success)
+	assert.Equal(t, 502, result.StatusCode)
+	assert.Equal(t, "expected status code 200 but got 502", result.Error)
+}
+
+func TestProbe_Run_HTTPUsesExpectedStatus(t *testing.T) {
+	server := newTestServer(301, "", 0)
+	defer server.Close()
+
+	result := Run(types.ProbeDefinition{Type: consts.PROBE_TYPE_HTTP, Target: server.URL, ExpectedStatus: 301})
+
+	assert.True(t, result.Success)
+}
+
+func TestProbe_Run_HTTPFailsOnBodyMismatch(t *testing.T) {
+	server := newTestServer(200, "maintenance", 0)
+	defer server.Close()
+
+	result := Run(types.ProbeDefinition{Type: consts.PROBE_TYPE_HTTP, Target: server.URL, BodyRegex: "^ok$"})
+
+	assert.False(t, result.Success)
+	assert.Equal(t, "body did not match ^ok$", result.Error)
+}
+
+func TestProbe_Run_HTTPFailsOverLatencyBudget(t *testing.T) {
+	server := newTestServer(200, "", time.Millisecond*50)
+	defer server.Close()
+
+	result := Run(types.ProbeDefinition{Type: consts.PROBE_TYPE_HTTP, Target: server.URL, LatencyBudget: 10})
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "over the budget of 10ms")
+	assert.GreaterOrEqual(t, result.Latency, int64(50))
+}
+
+func TestProbe_Run_TCPSucceeds(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	result := Run(types.ProbeDefinition{Name: "mongo", Type: consts.PROBE_TYPE_TCP, Target: listener.Addr().String()})
+
+	assert.True(t, result.Success)
+	assert.Equal(t, listener.Addr().String(), result.Target)
+}
+
+func TestProbe_Run_TCPFailsWhenClosed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	result := Run(types.ProbeDefinition{Type: consts.PROBE_TYPE_TCP, Target: address, Timeout: 1})
+
+	assert.False(t, result.Success)
+	assert.NotEqual(t, "", result.Error)
+}
+
+func TestProbe_Run_FailsOnUnknownType(t *testing.T) {
+	result := Run(types.ProbeDefinition{Type: "icmp", Target: "localhost"})
+
+	assert.False(t, result.Success)
+	assert.Equal(t, "unknown probe type: icmp", result.Error)
+}
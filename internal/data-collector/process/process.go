@@ -0,0 +1,69 @@
+package process
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+const (
+	// maxCmdlineLength is the maximum amount of characters kept from a process command line
+	maxCmdlineLength = 256
+)
+
+var (
+	processWrapper wrapper.ProcessInformation = &wrapper.GopsProcess{}
+)
+
+// IsEnabled returns true if process snapshots should be collected
+func IsEnabled() bool {
+	enabled, err := strconv.ParseBool(utils.GetVariable(consts.PROCESS_SNAPSHOT_ENABLED))
+	return err == nil && enabled
+}
+
+// GetSnapshot returns the top processes by cpu usage and by resident memory
+func GetSnapshot() *types.ProcessSnapshot {
+	processes, err := processWrapper.Processes()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return nil
+	}
+
+	count, err := strconv.Atoi(utils.GetVariable(consts.PROCESS_SNAPSHOT_COUNT))
+	if err != nil || count <= 0 {
+		count = 5
+	}
+
+	for i := range processes {
+		if cmdline := []rune(processes[i].Cmdline); len(cmdline) > maxCmdlineLength {
+			processes[i].Cmdline = string(cmdline[:maxCmdlineLength])
+		}
+	}
+
+	return &types.ProcessSnapshot{
+		TopCPU: getTop(processes, count, func(a, b types.Process) bool {
+			return a.CPUPercent > b.CPUPercent
+		}),
+		TopMemory: getTop(processes, count, func(a, b types.Process) bool {
+			return a.RSS > b.RSS
+		}),
+	}
+}
+
+// getTop returns the first count processes after sorting a copy of processes
+func getTop(processes []types.Process, count int, less func(a, b types.Process) bool) []types.Process {
+	sorted := make([]types.Process, len(processes))
+	copy(sorted, processes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	if len(sorted) > count {
+		sorted = sorted[:count]
+	}
+	return sorted
+}
@@ -0,0 +1,101 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/process/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name ProcessInformation
+
+var (
+	processData []types.Process = []types.Process{
+		{PID: 1, Name: "init", CPUPercent: 0.1, RSS: 100},
+		{PID: 2, Name: "nginx", CPUPercent: 50, RSS: 200},
+		{PID: 3, Name: "mongod", CPUPercent: 10, RSS: 5000},
+		{PID: 4, Name: "java", CPUPercent: 30, RSS: 3000},
+	}
+)
+
+func TestProcess_IsEnabled_ReadsVariable(t *testing.T) {
+	os.Clearenv()
+	assert.False(t, IsEnabled())
+
+	os.Setenv(consts.PROCESS_SNAPSHOT_ENABLED, "true")
+	assert.True(t, IsEnabled())
+
+	os.Setenv(consts.PROCESS_SNAPSHOT_ENABLED, "not a bool")
+	assert.False(t, IsEnabled())
+	os.Clearenv()
+}
+
+func TestProcess_GetSnapshot_ReturnsTopProcesses(t *testing.T) {
+	os.Clearenv()
+	os.Setenv(consts.PROCESS_SNAPSHOT_COUNT, "2")
+	wrapper := new(mocks.ProcessInformation)
+	wrapper.On("Processes").Return(processData, nil)
+
+	processWrapper = wrapper
+
+	snapshot := GetSnapshot()
+
+	assert.Equal(t, 2, len(snapshot.TopCPU))
+	assert.Equal(t, "nginx", snapshot.TopCPU[0].Name)
+	assert.Equal(t, "java", snapshot.TopCPU[1].Name)
+	assert.Equal(t, 2, len(snapshot.TopMemory))
+	assert.Equal(t, "mongod", snapshot.TopMemory[0].Name)
+	assert.Equal(t, "java", snapshot.TopMemory[1].Name)
+	wrapper.AssertExpectations(t)
+	os.Clearenv()
+}
+
+func TestProcess_GetSnapshot_TruncatesCmdline(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.ProcessInformation)
+	wrapper.On("Processes").Return([]types.Process{
+		{PID: 1, Name: "java", Cmdline: "java " + strings.Repeat("-Dkey=value ", 100)},
+	}, nil)
+
+	processWrapper = wrapper
+
+	snapshot := GetSnapshot()
+
+	assert.Equal(t, 1, len(snapshot.TopCPU))
+	assert.Equal(t, maxCmdlineLength, len(snapshot.TopCPU[0].Cmdline))
+	wrapper.AssertExpectations(t)
+}
+
+func TestProcess_GetSnapshot_TruncatesCmdlineOnRuneBoundary(t *testing.T) {
+	os.Clearenv()
+	wrapper := new(mocks.ProcessInformation)
+	wrapper.On("Processes").Return([]types.Process{
+		{PID: 1, Name: "python", Cmdline: "python " + strings.Repeat("é", maxCmdlineLength)},
+	}, nil)
+
+	processWrapper = wrapper
+
+	snapshot := GetSnapshot()
+
+	assert.True(t, utf8.ValidString(snapshot.TopCPU[0].Cmdline))
+	assert.Equal(t, maxCmdlineLength, utf8.RuneCountInString(snapshot.TopCPU[0].Cmdline))
+	wrapper.AssertExpectations(t)
+}
+
+func TestProcess_GetSnapshot_HandlesError(t *testing.T) {
+	wrapper := new(mocks.ProcessInformation)
+	wrapper.On("Processes").Return(nil, fmt.Errorf("platform not supported"))
+
+	processWrapper = wrapper
+
+	snapshot := GetSnapshot()
+
+	assert.Nil(t, snapshot)
+	wrapper.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/client"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/scheduler"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+// Fetch requests the effective configuration of this agent from the api
+func Fetch(c client.Client, group string) (types.RemoteConfig, error) {
+	body, statusCode, err := c.Get("config/?group=" + url.QueryEscape(group))
+	if err != nil {
+		return types.RemoteConfig{}, err
+	}
+	if statusCode != http.StatusOK {
+		return types.RemoteConfig{}, fmt.Errorf("unexpected status code %d when fetching the remote config", statusCode)
+	}
+
+	var response types.RemoteConfigResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return types.RemoteConfig{}, err
+	}
+	if len(response.Data) == 0 {
+		return types.RemoteConfig{}, fmt.Errorf("the api returned no remote config")
+	}
+	return response.Data[0], nil
+}
+
+// Watch fetches the remote configuration straight away and then every interval, calling apply
+// whenever it changes. A failed fetch keeps the last configuration. Calling the returned function
+// stops watching
+func Watch(c client.Client, group string, interval time.Duration, apply func(types.RemoteConfig)) func() {
+	var (
+		current types.RemoteConfig
+		fetched bool
+		lock    sync.Mutex
+	)
+	refresh := func() {
+		remoteConfig, err := Fetch(c, group)
+		if err != nil {
+			logger.Instance().Error(fmt.Sprintf("Failed to fetch the remote config (%s)", err.Error()))
+			return
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		if fetched && reflect.DeepEqual(current, remoteConfig) {
+			return
+		}
+		current, fetched = remoteConfig, true
+		logger.Instance().Info("Applying a new remote config")
+		apply(remoteConfig)
+	}
+
+	refresh()
+	return scheduler.After(interval, refresh)
+}
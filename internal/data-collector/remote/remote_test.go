@@ -0,0 +1,73 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/remote/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name Client
+
+func getResponse(t *testing.T, remoteConfig ...types.RemoteConfig) []byte {
+	body, err := json.Marshal(types.RemoteConfigResponse{
+		Data:       remoteConfig,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	})
+	assert.Nil(t, err)
+	return body
+}
+
+func TestRemote_Fetch_ReturnsConfig(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("Get", "config/?group=web+servers").
+		Return(getResponse(t, types.RemoteConfig{Interval: 10}), http.StatusOK, nil)
+
+	remoteConfig, err := Fetch(client, "web servers")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, remoteConfig.Interval)
+	client.AssertExpectations(t)
+}
+
+func TestRemote_Fetch_ReturnsErrors(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("Get", "config/?group=").Return(nil, 0, errors.New("connection refused")).Once()
+	client.On("Get", "config/?group=").Return([]byte("{}"), http.StatusInternalServerError, nil).Once()
+	client.On("Get", "config/?group=").Return(getResponse(t), http.StatusOK, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		_, err := Fetch(client, "")
+		assert.NotNil(t, err)
+	}
+	client.AssertExpectations(t)
+}
+
+func TestRemote_Watch_AppliesOnlyChanges(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("Get", "config/?group=").Return(getResponse(t, types.RemoteConfig{Interval: 10}), http.StatusOK, nil).Twice()
+	client.On("Get", "config/?group=").Return(nil, 0, errors.New("connection refused")).Once()
+	client.On("Get", "config/?group=").Return(getResponse(t, types.RemoteConfig{Interval: 20}), http.StatusOK, nil)
+
+	applied := make(chan types.RemoteConfig, 10)
+	stop := Watch(client, "", time.Millisecond*10, func(remoteConfig types.RemoteConfig) {
+		applied <- remoteConfig
+	})
+	defer stop()
+
+	assert.Equal(t, 10, (<-applied).Interval)
+	select {
+	case remoteConfig := <-applied:
+		assert.Equal(t, 20, remoteConfig.Interval)
+	case <-time.After(time.Second):
+		t.Fatal("the changed remote config was not applied")
+	}
+	stop()
+	assert.Len(t, applied, 0)
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Every runs task in the background straight away and then again every interval until the
+// returned function is called. A task which is already running is not interrupted
+func Every(interval time.Duration, task func()) func() {
+	return schedule(interval, task, true)
+}
+
+// After runs task in the background every interval, starting after the first interval, until
+// the returned function is called. A task which is already running is not interrupted
+func After(interval time.Duration, task func()) func() {
+	return schedule(interval, task, false)
+}
+
+// schedule runs task every interval, optionally straight away, until the returned function is called
+func schedule(interval time.Duration, task func(), immediately bool) func() {
+	done := make(chan struct{})
+	go func() {
+		if immediately {
+			task()
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				task()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Every_RunsTaskRepeatedly(t *testing.T) {
+	runs := make(chan bool, 3)
+
+	Every(time.Millisecond, func() {
+		select {
+		case runs <- true:
+		default:
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case ran := <-runs:
+			assert.True(t, ran)
+		case <-time.After(time.Second):
+			t.Fatal("task was not run")
+		}
+	}
+}
+
+func TestScheduler_Every_StopsRunningTask(t *testing.T) {
+	runs := make(chan bool, 100)
+
+	stop := Every(time.Millisecond, func() {
+		runs <- true
+	})
+	<-runs
+	stop()
+	stop()
+	time.Sleep(time.Millisecond * 10)
+	count := len(runs)
+	time.Sleep(time.Millisecond * 10)
+
+	assert.Equal(t, count, len(runs))
+}
+
+func TestScheduler_After_WaitsForFirstInterval(t *testing.T) {
+	runs := make(chan bool, 1)
+
+	stop := After(time.Millisecond*50, func() {
+		select {
+		case runs <- true:
+		default:
+		}
+	})
+	defer stop()
+
+	assert.Equal(t, 0, len(runs))
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("task was not run")
+	}
+}
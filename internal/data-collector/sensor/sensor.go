@@ -0,0 +1,34 @@
+package sensor
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+)
+
+var (
+	sensorWrapper wrapper.SensorInformation = &wrapper.GopsSensor{}
+)
+
+// GetTemperatures returns all temperature sensor readings which could be read
+func GetTemperatures() []types.Temperature {
+	temperatures, err := sensorWrapper.Temperatures()
+	if err != nil {
+		// Some sensors commonly fail to read, so only the failed readings are dropped
+		logger.Instance().Warning(err.Error())
+	}
+	if temperatures == nil {
+		return []types.Temperature{}
+	}
+	return temperatures
+}
+
+// GetFans returns all fan sensor readings
+func GetFans() []types.Fan {
+	fans, err := sensorWrapper.Fans()
+	if err != nil {
+		logger.Instance().Error(err.Error())
+		return []types.Fan{}
+	}
+	return fans
+}
@@ -0,0 +1,71 @@
+package sensor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/sensor/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate mockery --dir=../../ -r --name SensorInformation
+
+func TestSensor_GetTemperatures_ReturnsExpectedTemperatures(t *testing.T) {
+	wrapper := new(mocks.SensorInformation)
+	temperatures := []types.Temperature{
+		{SensorKey: "coretemp_core_0", Current: 45, High: 80, Critical: 100},
+	}
+	wrapper.On("Temperatures").Return(temperatures, nil)
+
+	sensorWrapper = wrapper
+
+	assert.Equal(t, temperatures, GetTemperatures())
+	wrapper.AssertExpectations(t)
+}
+
+func TestSensor_GetTemperatures_KeepsPartialReadings(t *testing.T) {
+	wrapper := new(mocks.SensorInformation)
+	temperatures := []types.Temperature{
+		{SensorKey: "coretemp_core_0", Current: 45},
+	}
+	wrapper.On("Temperatures").Return(temperatures, fmt.Errorf("failed to read acpitz"))
+
+	sensorWrapper = wrapper
+
+	assert.Equal(t, temperatures, GetTemperatures())
+	wrapper.AssertExpectations(t)
+}
+
+func TestSensor_GetTemperatures_HandlesError(t *testing.T) {
+	wrapper := new(mocks.SensorInformation)
+	wrapper.On("Temperatures").Return(nil, fmt.Errorf("platform not supported"))
+
+	sensorWrapper = wrapper
+
+	assert.Equal(t, []types.Temperature{}, GetTemperatures())
+	wrapper.AssertExpectations(t)
+}
+
+func TestSensor_GetFans_ReturnsExpectedFans(t *testing.T) {
+	wrapper := new(mocks.SensorInformation)
+	fans := []types.Fan{
+		{SensorKey: "nct6775_fan1", RPM: 1200},
+	}
+	wrapper.On("Fans").Return(fans, nil)
+
+	sensorWrapper = wrapper
+
+	assert.Equal(t, fans, GetFans())
+	wrapper.AssertExpectations(t)
+}
+
+func TestSensor_GetFans_HandlesError(t *testing.T) {
+	wrapper := new(mocks.SensorInformation)
+	wrapper.On("Fans").Return(nil, fmt.Errorf("bad pattern"))
+
+	sensorWrapper = wrapper
+
+	assert.Equal(t, []types.Fan{}, GetFans())
+	wrapper.AssertExpectations(t)
+}
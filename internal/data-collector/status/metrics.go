@@ -0,0 +1,277 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+const (
+	metricPrefix = "shm_"
+	// gauge is the type of a metric which can go up and down
+	gauge = "gauge"
+	// counter is the type of a metric which only goes up
+	counter = "counter"
+)
+
+// sample is a single value of a metric
+type sample struct {
+	labels []string // alternating label names and values
+	value  float64
+}
+
+// metric is a gauge or counter with every sample it has
+type metric struct {
+	name    string
+	kind    string
+	help    string
+	samples []sample
+}
+
+// WriteMetrics writes the state of the agent and its last collected health data in the Prometheus
+// text exposition format. Health metrics are left out until the first sample has been collected,
+// and afterwards for every collector which did not run for it
+func (r *Recorder) WriteMetrics(w io.Writer) error {
+	status := r.Status()
+	r.lock.RLock()
+	health := r.health
+	collectors := r.collectors
+	r.lock.RUnlock()
+
+	metrics := []metric{
+		{"agent_info", gauge, "Information about the agent, the value is always 1",
+			[]sample{{[]string{"agent_id", status.AgentID, "version", status.Version}, 1}}},
+		{"agent_start_time_seconds", gauge, "Time the agent started in seconds since the epoch",
+			[]sample{{nil, seconds(status.StartTime)}}},
+		{"agent_last_collect_time_seconds", gauge, "Time health data was last collected in seconds since the epoch",
+			[]sample{{nil, seconds(status.LastCollectTime)}}},
+		{"agent_last_send_time_seconds", gauge, "Time of the last successful request to the api in seconds since the epoch",
+			[]sample{{nil, seconds(status.LastSendTime)}}},
+		{"agent_queue_depth", gauge, "Packets buffered on disk waiting to be sent to the api",
+			[]sample{{nil, float64(status.QueueDepth)}}},
+		{"agent_request_errors_total", counter, "Failed requests to the api by endpoint since the agent started", errorSamples(status.Errors)},
+	}
+	if health != nil {
+		metrics = append(metrics, healthMetrics(health, collectors)...)
+	}
+
+	for _, metric := range metrics {
+		if err := metric.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write writes the metric unless it has no samples
+func (m metric) write(w io.Writer) error {
+	if len(m.samples) == 0 {
+		return nil
+	}
+
+	name := metricPrefix + m.name
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, m.help, name, m.kind); err != nil {
+		return err
+	}
+	for _, sample := range m.samples {
+		labels := []string{}
+		for i := 0; i+1 < len(sample.labels); i += 2 {
+			labels = append(labels, fmt.Sprintf("%s=\"%s\"", sample.labels[i], escapeLabel(sample.labels[i+1])))
+		}
+		line := name
+		if len(labels) > 0 {
+			line += "{" + strings.Join(labels, ",") + "}"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s\n", line, strconv.FormatFloat(sample.value, 'g', -1, 64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// healthMetrics returns a metric for every value of the health data which was collected by one of the collectors
+func healthMetrics(health *types.Health, collectors map[string]bool) []metric {
+	metrics := []metric{
+		{"uptime_seconds", gauge, "Time since the host booted", []sample{{nil, float64(health.Uptime)}}},
+	}
+	if collectors[consts.COLLECTOR_CPU] {
+		metrics = append(metrics, cpuMetrics(health)...)
+	}
+	if collectors[consts.COLLECTOR_MEMORY] {
+		metrics = append(metrics,
+			metric{"memory_total_bytes", gauge, "Total memory", []sample{{nil, float64(health.Memory.Total)}}},
+			metric{"memory_used_bytes", gauge, "Used memory", []sample{{nil, float64(health.Memory.Used)}}},
+			metric{"memory_available_bytes", gauge, "Memory available to new processes", []sample{{nil, float64(health.Memory.Available)}}},
+			metric{"memory_cached_bytes", gauge, "Memory used as cache", []sample{{nil, float64(health.Memory.Cached)}}},
+			metric{"swap_total_bytes", gauge, "Total swap", []sample{{nil, float64(health.Memory.SwapTotal)}}},
+			metric{"swap_used_bytes", gauge, "Used swap", []sample{{nil, float64(health.Memory.SwapUsed)}}},
+		)
+	}
+	if collectors[consts.COLLECTOR_LOAD] {
+		metrics = append(metrics,
+			metric{"load1", gauge, "Load average over 1 minute", []sample{{nil, health.Load.Load1}}},
+			metric{"load5", gauge, "Load average over 5 minutes", []sample{{nil, health.Load.Load5}}},
+			metric{"load15", gauge, "Load average over 15 minutes", []sample{{nil, health.Load.Load15}}},
+			metric{"procs_total", gauge, "Processes on the host", []sample{{nil, float64(health.Load.ProcsTotal)}}},
+			metric{"procs_running", gauge, "Running processes", []sample{{nil, float64(health.Load.ProcsRunning)}}},
+			metric{"procs_blocked", gauge, "Processes blocked on io", []sample{{nil, float64(health.Load.ProcsBlocked)}}},
+		)
+	}
+	if collectors[consts.COLLECTOR_DISK] {
+		metrics = append(metrics, diskMetrics(health)...)
+	}
+	if collectors[consts.COLLECTOR_DISK_IO] {
+		metrics = append(metrics, diskIOMetrics(health)...)
+	}
+	if collectors[consts.COLLECTOR_NETWORK] {
+		metrics = append(metrics, networkMetrics(health)...)
+	}
+	if collectors[consts.COLLECTOR_SENSOR] {
+		metrics = append(metrics, sensorMetrics(health)...)
+	}
+	return metrics
+}
+
+// cpuMetrics returns the usage of the cpu and each of its cores
+func cpuMetrics(health *types.Health) []metric {
+	cpuUsage, cpuModes := []sample{}, []sample{}
+	for _, utilization := range append([]types.CPUUtilization{health.CPU.Total}, health.CPU.Cores...) {
+		cpuUsage = append(cpuUsage, sample{[]string{"cpu", utilization.CPU}, utilization.Usage})
+		cpuModes = append(cpuModes,
+			sample{[]string{"cpu", utilization.CPU, "mode", "user"}, utilization.User},
+			sample{[]string{"cpu", utilization.CPU, "mode", "system"}, utilization.System},
+			sample{[]string{"cpu", utilization.CPU, "mode", "iowait"}, utilization.Iowait},
+			sample{[]string{"cpu", utilization.CPU, "mode", "steal"}, utilization.Steal},
+		)
+	}
+
+	return []metric{
+		{"cpu_usage_percent", gauge, "Percentage of time the cpu was busy", cpuUsage},
+		{"cpu_mode_percent", gauge, "Percentage of time the cpu spent in a mode", cpuModes},
+	}
+}
+
+// diskMetrics returns the usage of every filesystem
+func diskMetrics(health *types.Health) []metric {
+	metrics := []metric{}
+	values := []struct {
+		name  string
+		help  string
+		value func(disk types.Disk) float64
+	}{
+		{"disk_total_bytes", "Size of the filesystem", func(disk types.Disk) float64 { return float64(disk.Total) }},
+		{"disk_used_bytes", "Used space of the filesystem", func(disk types.Disk) float64 { return float64(disk.Used) }},
+		{"disk_free_bytes", "Free space of the filesystem", func(disk types.Disk) float64 { return float64(disk.Free) }},
+		{"disk_inodes_total", "Inodes of the filesystem", func(disk types.Disk) float64 { return float64(disk.InodesTotal) }},
+		{"disk_inodes_used", "Used inodes of the filesystem", func(disk types.Disk) float64 { return float64(disk.InodesUsed) }},
+	}
+	for _, diskMetric := range values {
+		samples := []sample{}
+		for _, disk := range health.Disks {
+			samples = append(samples, sample{[]string{"device", disk.Device, "mountpoint", disk.Mountpoint, "fstype", disk.Fstype}, diskMetric.value(disk)})
+		}
+		metrics = append(metrics, metric{diskMetric.name, gauge, diskMetric.help, samples})
+	}
+	return metrics
+}
+
+// diskIOMetrics returns the io rates of every device
+func diskIOMetrics(health *types.Health) []metric {
+	metrics := []metric{}
+
+	values := []struct {
+		name  string
+		help  string
+		value func(io types.DiskIO) float64
+	}{
+		{"disk_read_bytes_per_second", "Bytes read from the device per second", func(io types.DiskIO) float64 { return io.ReadBytesPerSecond }},
+		{"disk_write_bytes_per_second", "Bytes written to the device per second", func(io types.DiskIO) float64 { return io.WriteBytesPerSecond }},
+		{"disk_reads_per_second", "Reads from the device per second", func(io types.DiskIO) float64 { return io.ReadsPerSecond }},
+		{"disk_writes_per_second", "Writes to the device per second", func(io types.DiskIO) float64 { return io.WritesPerSecond }},
+	}
+	for _, diskIOMetric := range values {
+		samples := []sample{}
+		for _, io := range health.DiskIO {
+			samples = append(samples, sample{[]string{"device", io.Device}, diskIOMetric.value(io)})
+		}
+		metrics = append(metrics, metric{diskIOMetric.name, gauge, diskIOMetric.help, samples})
+	}
+	return metrics
+}
+
+// networkMetrics returns the rates of every network interface
+func networkMetrics(health *types.Health) []metric {
+	metrics := []metric{}
+
+	values := []struct {
+		name  string
+		help  string
+		value func(network types.NetworkIO) float64
+	}{
+		{"network_receive_bytes_per_second", "Bytes received per second", func(network types.NetworkIO) float64 { return network.RxBytesPerSecond }},
+		{"network_transmit_bytes_per_second", "Bytes transmitted per second", func(network types.NetworkIO) float64 { return network.TxBytesPerSecond }},
+		{"network_receive_packets_per_second", "Packets received per second", func(network types.NetworkIO) float64 { return network.RxPacketsPerSecond }},
+		{"network_transmit_packets_per_second", "Packets transmitted per second", func(network types.NetworkIO) float64 { return network.TxPacketsPerSecond }},
+		{"network_receive_errors_per_second", "Receive errors per second", func(network types.NetworkIO) float64 { return network.RxErrorsPerSecond }},
+		{"network_transmit_errors_per_second", "Transmit errors per second", func(network types.NetworkIO) float64 { return network.TxErrorsPerSecond }},
+		{"network_receive_drops_per_second", "Dropped received packets per second", func(network types.NetworkIO) float64 { return network.RxDropsPerSecond }},
+		{"network_transmit_drops_per_second", "Dropped transmitted packets per second", func(network types.NetworkIO) float64 { return network.TxDropsPerSecond }},
+	}
+	for _, networkMetric := range values {
+		samples := []sample{}
+		for _, network := range health.Network {
+			samples = append(samples, sample{[]string{"interface", network.Interface}, networkMetric.value(network)})
+		}
+		metrics = append(metrics, metric{networkMetric.name, gauge, networkMetric.help, samples})
+	}
+	return metrics
+}
+
+// sensorMetrics returns the temperature and fan speed of every sensor
+func sensorMetrics(health *types.Health) []metric {
+
+	temperatures := []sample{}
+	for _, temperature := range health.Temperatures {
+		temperatures = append(temperatures, sample{[]string{"sensor", temperature.SensorKey}, temperature.Current})
+	}
+	fans := []sample{}
+	for _, fan := range health.Fans {
+		fans = append(fans, sample{[]string{"sensor", fan.SensorKey}, float64(fan.RPM)})
+	}
+	return []metric{
+		{"temperature_celsius", gauge, "Current temperature of the sensor", temperatures},
+		{"fan_rpm", gauge, "Current speed of the fan", fans},
+	}
+}
+
+// errorSamples returns a sample per endpoint, sorted so the output is stable
+func errorSamples(errors map[string]int) []sample {
+	samples := []sample{}
+	for endpoint, count := range errors {
+		samples = append(samples, sample{[]string{"endpoint", endpoint}, float64(count)})
+	}
+	sortSamples(samples)
+	return samples
+}
+
+// sortSamples sorts samples by their label values
+func sortSamples(samples []sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].labels, "\x00") < strings.Join(samples[j].labels, "\x00")
+	})
+}
+
+// seconds converts nanoseconds to seconds
+func seconds(nanoseconds int64) float64 {
+	return float64(nanoseconds) / 1e9
+}
+
+// escapeLabel escapes a label value as required by the text exposition format
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,171 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/client"
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+var (
+	now = time.Now
+)
+
+// Recorder keeps the last collected health data and the outcome of requests to the api so they can
+// be served by the local /metrics and /status endpoints
+type Recorder struct {
+	agentID     string
+	started     time.Time
+	health      *types.Health
+	collectors  map[string]bool
+	lastCollect time.Time
+	lastSend    time.Time
+	errors      map[string]int
+	queueDepth  func() int
+	lock        sync.RWMutex
+}
+
+// NewRecorder returns a recorder for an agent which has not collected or sent anything yet
+func NewRecorder(agentID string) *Recorder {
+	return &Recorder{
+		agentID: agentID,
+		started: now(),
+		errors:  map[string]int{},
+	}
+}
+
+// TrackQueue sets the function which returns the amount of packets waiting to be sent
+func (r *Recorder) TrackQueue(queueDepth func() int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.queueDepth = queueDepth
+}
+
+// RecordHealth keeps health data as the last collected sample, along with the collectors which ran for it
+func (r *Recorder) RecordHealth(health types.Health, collectors []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.health = &health
+	r.collectors = map[string]bool{}
+	for _, collector := range collectors {
+		r.collectors[collector] = true
+	}
+	r.lastCollect = now()
+}
+
+// RecordRequest counts a failed request to an endpoint or keeps the time of a successful one
+func (r *Recorder) RecordRequest(url string, statusCode int, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err != nil || statusCode >= 400 {
+		// Query strings would create an endpoint per value
+		endpoint := strings.SplitN(url, "?", 2)[0]
+		r.errors[endpoint]++
+		return
+	}
+	r.lastSend = now()
+}
+
+// Status returns the current state of the agent
+func (r *Recorder) Status() types.AgentStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	status := types.AgentStatus{
+		AgentID:         r.agentID,
+		Version:         consts.VERSION,
+		StartTime:       r.started.UTC().UnixNano(),
+		LastCollectTime: unixNano(r.lastCollect),
+		LastSendTime:    unixNano(r.lastSend),
+		Errors:          map[string]int{},
+	}
+	if r.queueDepth != nil {
+		status.QueueDepth = r.queueDepth()
+	}
+	for endpoint, count := range r.errors {
+		status.Errors[endpoint] = count
+	}
+	return status
+}
+
+// Handler returns the handler of the /metrics and /status endpoints
+func (r *Recorder) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteMetrics(w)
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(r.Status())
+	})
+	return mux
+}
+
+// Serve serves the endpoints of the recorder on address in the background. The returned function
+// stops serving, waiting for requests being handled until ctx is done
+func Serve(address string, r *Recorder) (func(ctx context.Context) error, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{
+		Handler:      r.Handler(),
+		ReadTimeout:  time.Second * 10,
+		WriteTimeout: time.Second * 10,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Instance().Errorf("local status endpoint stopped (%s)", err.Error())
+		}
+	}()
+	return server.Shutdown, nil
+}
+
+// WrapClient returns a client which records the outcome of every request made with c
+func WrapClient(c client.Client, r *Recorder) client.Client {
+	return &recordingClient{
+		Client:   c,
+		recorder: r,
+	}
+}
+
+type recordingClient struct {
+	client.Client
+	recorder *Recorder
+}
+
+// Get makes a GET request with the wrapped client and records its outcome
+func (c *recordingClient) Get(url string) ([]byte, int, error) {
+	body, statusCode, err := c.Client.Get(url)
+	c.recorder.RecordRequest(url, statusCode, err)
+	return body, statusCode, err
+}
+
+// Post makes a POST request with the wrapped client and records its outcome
+func (c *recordingClient) Post(url string, data io.Reader) ([]byte, int, error) {
+	body, statusCode, err := c.Client.Post(url, data)
+	c.recorder.RecordRequest(url, statusCode, err)
+	return body, statusCode, err
+}
+
+// unixNano returns the time in nanoseconds since the epoch or 0 for the zero time
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UTC().UnixNano()
+}
@@ -0,0 +1,100 @@
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/data-collector/status/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+//go:generate mockery --dir=../../ -r --name Client
+
+func TestStatus_WrapClient_RecordsRequests(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	recorder := NewRecorder("1")
+	recorder.TrackQueue(func() int { return 3 })
+	client := new(mocks.Client)
+	client.On("Post", "health/batch", mock.Anything).Return(nil, http.StatusOK, nil).Once()
+	client.On("Post", "health/batch", mock.Anything).Return(nil, http.StatusServiceUnavailable, nil).Once()
+	client.On("Get", "config/?group=web").Return(nil, 0, errors.New("connection refused"))
+
+	wrapped := WrapClient(client, recorder)
+	now = func() time.Time { return time.Unix(2000, 0) }
+	wrapped.Post("health/batch", bytes.NewReader(nil))
+	now = func() time.Time { return time.Unix(3000, 0) }
+	wrapped.Post("health/batch", bytes.NewReader(nil))
+	wrapped.Get("config/?group=web")
+
+	assert.Equal(t, types.AgentStatus{
+		AgentID:      "1",
+		Version:      "dev",
+		StartTime:    time.Unix(1000, 0).UnixNano(),
+		LastSendTime: time.Unix(2000, 0).UnixNano(),
+		QueueDepth:   3,
+		Errors:       map[string]int{"health/batch": 1, "config/": 1},
+	}, recorder.Status())
+	client.AssertExpectations(t)
+}
+
+func TestStatus_WriteMetrics_WritesTextExposition(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	recorder := NewRecorder("1")
+	recorder.RecordRequest("events/", 0, errors.New("connection refused"))
+
+	var metrics bytes.Buffer
+	assert.Nil(t, recorder.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "# TYPE shm_agent_info gauge\nshm_agent_info{agent_id=\"1\",version=\"dev\"} 1\n")
+	assert.Contains(t, metrics.String(), "# TYPE shm_agent_request_errors_total counter\nshm_agent_request_errors_total{endpoint=\"events/\"} 1\n")
+	assert.Contains(t, metrics.String(), "shm_agent_start_time_seconds 1000\n")
+	assert.NotContains(t, metrics.String(), "shm_load1")
+
+	recorder.RecordHealth(types.Health{
+		CPU:          types.CPU{Total: types.CPUUtilization{CPU: "cpu-total", Usage: 12.5, User: 10}},
+		Load:         types.Load{Load1: 0.5},
+		Disks:        []types.Disk{{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4", Used: 1024}},
+		Temperatures: []types.Temperature{{SensorKey: "core \"0\"", Current: 45}},
+	}, []string{consts.COLLECTOR_CPU, consts.COLLECTOR_LOAD, consts.COLLECTOR_DISK, consts.COLLECTOR_SENSOR})
+	metrics.Reset()
+	assert.Nil(t, recorder.WriteMetrics(&metrics))
+
+	assert.Contains(t, metrics.String(), "shm_agent_last_collect_time_seconds 1000\n")
+	assert.Contains(t, metrics.String(), "shm_cpu_usage_percent{cpu=\"cpu-total\"} 12.5\n")
+	assert.Contains(t, metrics.String(), "shm_cpu_mode_percent{cpu=\"cpu-total\",mode=\"user\"} 10\n")
+	assert.Contains(t, metrics.String(), "shm_load1 0.5\n")
+	assert.Contains(t, metrics.String(), "shm_disk_used_bytes{device=\"/dev/sda1\",mountpoint=\"/\",fstype=\"ext4\"} 1024\n")
+	assert.Contains(t, metrics.String(), "shm_temperature_celsius{sensor=\"core \\\"0\\\"\"} 45\n")
+	assert.Contains(t, metrics.String(), "# TYPE shm_procs_total gauge\n")
+	assert.Contains(t, metrics.String(), "# TYPE shm_disk_inodes_total gauge\n")
+	assert.NotContains(t, metrics.String(), "shm_fan_rpm")
+	assert.NotContains(t, metrics.String(), "shm_memory_total_bytes")
+	assert.NotContains(t, metrics.String(), "shm_network_")
+}
+
+func TestStatus_Handler_ServesStatus(t *testing.T) {
+	now = func() time.Time { return time.Unix(1000, 0) }
+	recorder := NewRecorder("1")
+	handler := recorder.Handler()
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	var status types.AgentStatus
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &status))
+	assert.Equal(t, "1", status.AgentID)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header().Get("Content-Type"))
+}
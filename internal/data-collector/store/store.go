@@ -18,6 +18,7 @@ type Store interface {
 
 	// Custom
 	GetAgentInformation() types.AgentInformation
+	SetAgentInformation(agentInformation types.AgentInformation) error
 }
 
 var (
@@ -28,6 +29,7 @@ var (
 // FileStore is a filestore implementation of a store
 type FileStore struct {
 	osWrapper wrapper.OperatingSystem
+	fileName  string
 }
 
 // Instance returns the active instance of the file store
@@ -36,27 +38,57 @@ func Instance(wrapper wrapper.OperatingSystem) Store {
 		return fileStore
 	}
 
-	fileStore = &FileStore{
+	fileStore = NewFileStore(wrapper, consts.AGENT_STORE_FILENAME)
+	return fileStore
+}
+
+// NewFileStore returns a file store which reads and writes a given file, creating it if it does not exist
+func NewFileStore(wrapper wrapper.OperatingSystem, fileName string) *FileStore {
+	store := &FileStore{
 		osWrapper: wrapper,
+		fileName:  fileName,
 	}
-	fileStore.createFileIfNotExists(consts.AGENT_STORE_FILENAME)
-	return fileStore
+	store.createFileIfNotExists(fileName)
+	return store
 }
 
 // Get reads a JSON file and returns the data
 func (s *FileStore) Get() ([]byte, error) {
-	return s.osWrapper.ReadFile(consts.AGENT_STORE_FILENAME)
+	return s.osWrapper.ReadFile(s.fileName)
 }
 
-// Store writes the desired JSON to a JSON file
+// Store writes the desired JSON to a JSON file only the owner can read, as it holds the agent secret. The data is
+// synced to a temporary file which then replaces the file, so a crash while writing never leaves a partially written file behind
 func (s *FileStore) Store(data []byte) error {
-	return s.osWrapper.WriteFile(consts.AGENT_STORE_FILENAME, data, 0644)
+	// Replacing the null device would break the system, writes to it are discarded (ie, during a dry run)
+	if s.fileName == os.DevNull {
+		return nil
+	}
+
+	tempName := s.fileName + ".tmp"
+	// A temporary file left behind by a crash keeps its permissions, so it is created from scratch
+	s.osWrapper.Remove(tempName)
+	file, err := s.osWrapper.OpenFile(tempName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		s.osWrapper.Remove(tempName)
+		return err
+	}
+	return s.osWrapper.Rename(tempName, s.fileName)
 }
 
 // createFileIfNotExists is a handy method which creates a given file if it does not exist
 func (s *FileStore) createFileIfNotExists(fileName string) error {
 	if _, err := s.osWrapper.Stat(fileName); s.osWrapper.IsNotExist(err) {
-		file, err := s.osWrapper.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		file, err := s.osWrapper.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
 		if err != nil {
 			return err
 		}
@@ -82,3 +114,12 @@ func (s *FileStore) GetAgentInformation() types.AgentInformation {
 	}
 	return agentInformation
 }
+
+// SetAgentInformation replaces the agent information in the current store
+func (s *FileStore) SetAgentInformation(agentInformation types.AgentInformation) error {
+	data, err := json.Marshal(agentInformation)
+	if err != nil {
+		return err
+	}
+	return s.Store(data)
+}
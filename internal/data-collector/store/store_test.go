@@ -60,6 +60,51 @@ func TestStoreStoreWritesCorrectData(t *testing.T) {
 	os.Remove(consts.AGENT_STORE_FILENAME)
 }
 
+func TestStoreStoreReplacesFileWithoutLeavingTemporaryFile(t *testing.T) {
+	resetStore()
+
+	store := Instance(&wrapper.DefaultOS{})
+	assert.Nil(t, store.Store([]byte("old data")))
+	assert.Nil(t, store.Store([]byte("new")))
+
+	data, err := os.ReadFile(consts.AGENT_STORE_FILENAME)
+	assert.Equal(t, []byte("new"), data)
+	assert.Nil(t, err)
+	_, err = os.Stat(consts.AGENT_STORE_FILENAME + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	os.Remove(consts.AGENT_STORE_FILENAME)
+}
+
+func TestStoreStoreHandlesTemporaryFileError(t *testing.T) {
+	wrapper := new(mocks.OperatingSystem)
+	wrapper.On("Remove", consts.AGENT_STORE_FILENAME+".tmp").Return(nil)
+	wrapper.On("OpenFile",
+		consts.AGENT_STORE_FILENAME+".tmp", os.O_CREATE|os.O_EXCL|os.O_WRONLY,
+		fs.FileMode(0600),
+	).Return(nil, fmt.Errorf("permission denied"))
+
+	store := FileStore{
+		osWrapper: wrapper,
+		fileName:  consts.AGENT_STORE_FILENAME,
+	}
+
+	err := store.Store([]byte("test data"))
+
+	assert.Equal(t, "permission denied", err.Error())
+	wrapper.AssertExpectations(t)
+}
+
+func TestStoreStoreDiscardsWritesToNullDevice(t *testing.T) {
+	store := NewFileStore(&wrapper.DefaultOS{}, os.DevNull)
+
+	assert.Nil(t, store.Store([]byte("test data")))
+
+	file, err := os.Stat(os.DevNull)
+	assert.Nil(t, err)
+	assert.True(t, file.Mode()&os.ModeDevice != 0)
+}
+
 func TestStoreCreatesFileWhenNotExists(t *testing.T) {
 	resetStore()
 
@@ -85,11 +130,12 @@ func TestStoreHandlesFileCreationError(t *testing.T) {
 	wrapper.On("IsNotExist", osErr).Return(true)
 	wrapper.On("OpenFile",
 		consts.AGENT_STORE_FILENAME, os.O_CREATE|os.O_APPEND|os.O_WRONLY,
-		fs.FileMode(0644),
+		fs.FileMode(0600),
 	).Return(nil, fmt.Errorf("failed to create file"))
 
 	store := FileStore{
 		osWrapper: wrapper,
+		fileName:  consts.AGENT_STORE_FILENAME,
 	}
 
 	err := store.createFileIfNotExists(consts.AGENT_STORE_FILENAME)
@@ -137,6 +183,7 @@ func TestGetAgentInformationReturnsEmptyAgentWhenError(t *testing.T) {
 
 	store := FileStore{
 		osWrapper: wrapper,
+		fileName:  consts.AGENT_STORE_FILENAME,
 	}
 
 	agent := store.GetAgentInformation()
@@ -144,3 +191,37 @@ func TestGetAgentInformationReturnsEmptyAgentWhenError(t *testing.T) {
 	assert.Equal(t, types.AgentInformation{}, agent)
 	wrapper.AssertExpectations(t)
 }
+
+func TestSetAgentInformationKeepsSecret(t *testing.T) {
+	resetStore()
+
+	agentInformation := types.AgentInformation{
+		ID:     uuid.New(),
+		Secret: "secret",
+	}
+
+	store := Instance(&wrapper.DefaultOS{})
+	err := store.SetAgentInformation(agentInformation)
+
+	assert.Nil(t, err)
+	assert.Equal(t, agentInformation, store.GetAgentInformation())
+
+	os.Remove(consts.AGENT_STORE_FILENAME)
+}
+
+func TestSetAgentInformationReplacesReadableFileWithPrivateFile(t *testing.T) {
+	resetStore()
+
+	os.WriteFile(consts.AGENT_STORE_FILENAME, []byte("{}"), 0644)
+	os.WriteFile(consts.AGENT_STORE_FILENAME+".tmp", []byte("left over"), 0644)
+
+	store := Instance(&wrapper.DefaultOS{})
+	err := store.SetAgentInformation(types.AgentInformation{ID: uuid.New(), Secret: "secret"})
+
+	assert.Nil(t, err)
+	file, err := os.Stat(consts.AGENT_STORE_FILENAME)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), file.Mode().Perm())
+
+	os.Remove(consts.AGENT_STORE_FILENAME)
+}
@@ -32,8 +32,15 @@ var (
 	logger    *standardLogger
 	_         Logger                  = (*standardLogger)(nil)
 	osWrapper wrapper.OperatingSystem = &wrapper.DefaultOS{}
+	console   io.Writer               = os.Stdout
 )
 
+// SetConsole sets where messages are written next to the log file, which is stdout by default.
+// It has no effect once the logger is in use
+func SetConsole(w io.Writer) {
+	console = w
+}
+
 // Instance returns the active instance of the logger
 func Instance() Logger {
 	if logger != nil {
@@ -43,7 +50,7 @@ func Instance() Logger {
 	if err != nil {
 		return nil
 	}
-	mw := io.MultiWriter(os.Stdout, file)
+	mw := io.MultiWriter(console, file)
 	logger = &standardLogger{
 		infoLogger:    log.New(mw, "INFO: ", log.Ldate|log.Ltime),
 		warningLogger: log.New(mw, "WARNING: ", log.Ldate|log.Ltime),
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"bytes"
 	"fmt"
 	"io/fs"
 	"os"
@@ -20,6 +21,7 @@ func resetLogger() {
 	logger = nil
 
 	osWrapper = &wrapper.DefaultOS{}
+	console = os.Stdout
 }
 
 func TestLogger_Instance_InitializesLogger(t *testing.T) {
@@ -48,6 +50,19 @@ func TestLogger_Instance_ReturnsNilWhenFileFails(t *testing.T) {
 	wrapper.AssertExpectations(t)
 }
 
+func TestLogger_SetConsole_WritesToConsole(t *testing.T) {
+	resetLogger()
+	defer resetLogger()
+	output := new(bytes.Buffer)
+	SetConsole(output)
+
+	Instance().Info("console message")
+
+	assert.Contains(t, output.String(), "INFO: ")
+	assert.Contains(t, output.String(), "console message")
+	osWrapper.Remove(consts.LOG_FILE)
+}
+
 func TestLogger_Info_WritesInfoTag(t *testing.T) {
 	resetLogger()
 
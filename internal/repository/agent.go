@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type agentRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IAgentRepository = (*agentRepository)(nil)
+)
+
+// NewAgentRepository returns an instanced enrolled agent repository
+func NewAgentRepository() IAgentRepository {
+	db, _ := database.Instance()
+
+	repository := &agentRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_AGENT),
+			collectionName: consts.COLLECTION_AGENT,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Every ingest request looks up the agent which sent it, and racing enrollments of the same
+	// agent must not leave two documents behind
+	repository.createUniqueIndex(bson.D{{Key: "agentID", Value: 1}})
+
+	return repository
+}
+
+// Find all enrolled agents given a certain query
+func (r *agentRepository) Find(query interface{}) ([]types.Agent, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.Agent
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.Agent
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// Upsert replaces the enrolled agent with the same agent ID, inserting it if it does not exist
+func (r *agentRepository) Upsert(data *types.Agent) error {
+	_, err := r.collection.ReplaceOne(r.db.Context(),
+		bson.M{"agentID": data.AgentID}, data,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		msg := fmt.Sprintf("failed to upsert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
+// InsertIfNotExists inserts the agent unless an agent with the same agent ID already exists
+func (r *agentRepository) InsertIfNotExists(data *types.Agent) error {
+	_, err := r.collection.UpdateOne(r.db.Context(),
+		bson.M{"agentID": data.AgentID},
+		bson.M{"$setOnInsert": data},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
+// Revoke marks an enrolled agent as revoked, returning false if the agent is not enrolled
+func (r *agentRepository) Revoke(agentID string) (bool, error) {
+	res, err := r.collection.UpdateOne(r.db.Context(),
+		bson.M{"agentID": agentID},
+		bson.M{"$set": bson.M{"revoked": true}},
+	)
+	if err != nil {
+		msg := fmt.Sprintf("failed to update data in collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return false, fmt.Errorf(msg)
+	}
+	return res.MatchedCount > 0, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type certificateRepository struct {
+	*baseRepository
+}
+
+var (
+	_ ICertificateRepository = (*certificateRepository)(nil)
+)
+
+// NewCertificateRepository returns an instanced certificate repository
+func NewCertificateRepository() ICertificateRepository {
+	db, _ := database.Instance()
+
+	repository := &certificateRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_CERTIFICATE),
+			collectionName: consts.COLLECTION_CERTIFICATE,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Certificates are looked up fleet wide by expiry and replaced by agent and source
+	repository.createIndex(bson.D{{Key: "notAfter", Value: 1}})
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "source", Value: 1}})
+
+	return repository
+}
+
+// Find all certificates given a certain query
+func (r *certificateRepository) Find(query interface{}) ([]types.Certificate, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all certificates given a certain query and options
+func (r *certificateRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Certificate, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.Certificate
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.Certificate
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// ReplaceForAgent replaces all certificates an agent has reported, sources which are not part of data are removed.
+// The new certificates are inserted before the old ones are removed, so a failed insert keeps the old ones
+func (r *certificateRepository) ReplaceForAgent(agentID string, data []types.Certificate) error {
+	documents := make([]interface{}, 0, len(data))
+	ids := make([]primitive.ObjectID, 0, len(data))
+	for i := range data {
+		documents = append(documents, data[i])
+		ids = append(ids, data[i].ID)
+	}
+
+	if len(documents) > 0 {
+		_, err := r.collection.InsertMany(r.db.Context(), documents)
+		if err != nil {
+			msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+			r.log.Error(msg)
+			return fmt.Errorf(msg)
+		}
+	}
+
+	_, err := r.collection.DeleteMany(r.db.Context(), bson.M{"agentID": agentID, "_id": bson.M{"$nin": ids}})
+	if err != nil {
+		msg := fmt.Sprintf("failed to delete data from collection: %s (%s)", r.collectionName, err.Error())
+		r.log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
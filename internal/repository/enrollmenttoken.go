@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type enrollmentTokenRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IEnrollmentTokenRepository = (*enrollmentTokenRepository)(nil)
+)
+
+// NewEnrollmentTokenRepository returns an instanced enrollment token repository
+func NewEnrollmentTokenRepository() IEnrollmentTokenRepository {
+	db, _ := database.Instance()
+
+	repository := &enrollmentTokenRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_ENROLLMENT_TOKEN),
+			collectionName: consts.COLLECTION_ENROLLMENT_TOKEN,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Tokens are always looked up by their hash
+	repository.createIndex(bson.D{{Key: "hash", Value: 1}})
+
+	return repository
+}
+
+// Insert a single enrollment token record into the database
+func (r *enrollmentTokenRepository) Insert(data *types.EnrollmentToken) (string, error) {
+	res, err := r.collection.InsertOne(r.db.Context(), data)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return "", fmt.Errorf(msg)
+	}
+	return fmt.Sprintf("%x", res.InsertedID), nil
+}
+
+// Use counts a use of the token with the given hash which was created for the given agent (or for any agent
+// if agentID is empty), returning false if no such token exists or it is used up or expired.
+// Checking and counting happen in one operation so a token can not be used twice at once
+func (r *enrollmentTokenRepository) Use(hash string, agentID string, now int64) (bool, error) {
+	var agent interface{} = agentID
+	if agentID == "" {
+		agent = bson.M{"$in": bson.A{nil, ""}}
+	}
+	err := r.collection.FindOneAndUpdate(r.db.Context(),
+		bson.M{
+			"hash":    hash,
+			"agentID": agent,
+			"$expr":   bson.M{"$lt": bson.A{"$uses", "$maxUses"}},
+			"$or": bson.A{
+				bson.M{"expireTime": 0},
+				bson.M{"expireTime": bson.M{"$gt": now}},
+			},
+		},
+		bson.M{"$inc": bson.M{"uses": 1}},
+	).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to update data in collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return false, fmt.Errorf(msg)
+	}
+	return true, nil
+}
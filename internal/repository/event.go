@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eventRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IEventRepository = (*eventRepository)(nil)
+)
+
+// NewEventRepository returns an instanced log event repository
+func NewEventRepository() IEventRepository {
+	db, _ := database.Instance()
+
+	repository := &eventRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_EVENT),
+			collectionName: consts.COLLECTION_EVENT,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Log events are always looked up by agent and the time the agent saw them
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "eventTime", Value: -1}})
+
+	return repository
+}
+
+// Find all log events given a certain query
+func (r *eventRepository) Find(query interface{}) ([]types.Event, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all log events given a certain query and options
+func (r *eventRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Event, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.Event
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.Event
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// Insert a single log event into the database
+func (r *eventRepository) Insert(data *types.Event) (string, error) {
+	res, err := r.collection.InsertOne(r.db.Context(), data)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return "", fmt.Errorf(msg)
+	}
+	return fmt.Sprintf("%x", res.InsertedID), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/PR-Developers/server-health-monitor/internal/logger"
 	"github.com/PR-Developers/server-health-monitor/internal/types"
 	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -23,7 +24,7 @@ var (
 func NewHealthRepository() IHealthRepository {
 	db, _ := database.Instance()
 
-	return &healthRepository{
+	repository := &healthRepository{
 		baseRepository: &baseRepository{
 			db:             db,
 			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_HEALTH),
@@ -31,6 +32,11 @@ func NewHealthRepository() IHealthRepository {
 			log:            logger.Instance(),
 		},
 	}
+
+	// New health data is looked up by agent and the time the api received it
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "receivedTime", Value: -1}})
+
+	return repository
 }
 
 // Find all health data given a certain query
@@ -70,3 +76,18 @@ func (r *healthRepository) Insert(data *types.Health) (string, error) {
 	}
 	return fmt.Sprintf("%x", res.InsertedID), nil
 }
+
+// InsertMany inserts multiple health records into the database at once
+func (r *healthRepository) InsertMany(data []types.Health) error {
+	documents := make([]interface{}, 0, len(data))
+	for i := range data {
+		documents = append(documents, data[i])
+	}
+	_, err := r.collection.InsertMany(r.db.Context(), documents)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
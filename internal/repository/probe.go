@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type probeRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IProbeRepository = (*probeRepository)(nil)
+)
+
+// NewProbeRepository returns an instanced probe result repository
+func NewProbeRepository() IProbeRepository {
+	db, _ := database.Instance()
+
+	repository := &probeRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_PROBE),
+			collectionName: consts.COLLECTION_PROBE,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Probe results are always looked up by agent and time
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "createTime", Value: -1}})
+
+	return repository
+}
+
+// Find all probe results given a certain query
+func (r *probeRepository) Find(query interface{}) ([]types.ProbeResult, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all probe results given a certain query and options
+func (r *probeRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.ProbeResult, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.ProbeResult
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.ProbeResult
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// Insert a single probe result into the database
+func (r *probeRepository) Insert(data *types.ProbeResult) (string, error) {
+	res, err := r.collection.InsertOne(r.db.Context(), data)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return "", fmt.Errorf(msg)
+	}
+	return fmt.Sprintf("%x", res.InsertedID), nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type processRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IProcessRepository = (*processRepository)(nil)
+)
+
+// NewProcessRepository returns an instanced process snapshot repository
+func NewProcessRepository() IProcessRepository {
+	db, _ := database.Instance()
+
+	repository := &processRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_PROCESS),
+			collectionName: consts.COLLECTION_PROCESS,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Snapshots are always looked up by agent and time
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "createTime", Value: -1}})
+
+	return repository
+}
+
+// Find all process snapshots given a certain query
+func (r *processRepository) Find(query interface{}) ([]types.ProcessSnapshot, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all process snapshots given a certain query and options
+func (r *processRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.ProcessSnapshot, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.ProcessSnapshot
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.ProcessSnapshot
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// Insert a single process snapshot into the database
+func (r *processRepository) Insert(data *types.ProcessSnapshot) (string, error) {
+	res, err := r.collection.InsertOne(r.db.Context(), data)
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return "", fmt.Errorf(msg)
+	}
+	return fmt.Sprintf("%x", res.InsertedID), nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type rebootRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IRebootRepository = (*rebootRepository)(nil)
+)
+
+// NewRebootRepository returns an instanced host reboot repository
+func NewRebootRepository() IRebootRepository {
+	db, _ := database.Instance()
+
+	repository := &rebootRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_REBOOT),
+			collectionName: consts.COLLECTION_REBOOT,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Reboots are looked up by agent and time, or across every agent by time. Every new reboot
+	// is also looked up by its boot time, and only recorded once per boot window
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "createTime", Value: -1}})
+	repository.createIndex(bson.D{{Key: "createTime", Value: -1}})
+	repository.createIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "bootTime", Value: 1}})
+	repository.createUniqueIndex(bson.D{{Key: "agentID", Value: 1}, {Key: "bootWindow", Value: 1}})
+
+	return repository
+}
+
+// Find all host reboots given a certain query
+func (r *rebootRepository) Find(query interface{}) ([]types.Reboot, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all host reboots given a certain query and options
+func (r *rebootRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Reboot, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.Reboot
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.Reboot
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// InsertIfNotExists inserts a host reboot unless the agent already has one in the same boot window,
+// returning true if it was inserted
+func (r *rebootRepository) InsertIfNotExists(data *types.Reboot) (bool, error) {
+	res, err := r.collection.UpdateOne(r.db.Context(),
+		bson.M{"agentID": data.AgentID, "bootWindow": data.BootWindow},
+		bson.M{"$setOnInsert": data},
+		options.Update().SetUpsert(true),
+	)
+	// A concurrent insert of the same reboot won the race
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to insert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return false, fmt.Errorf(msg)
+	}
+	return res.UpsertedCount > 0, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/database"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type remoteConfigRepository struct {
+	*baseRepository
+}
+
+var (
+	_ IRemoteConfigRepository = (*remoteConfigRepository)(nil)
+)
+
+// NewRemoteConfigRepository returns an instanced remote configuration repository
+func NewRemoteConfigRepository() IRemoteConfigRepository {
+	db, _ := database.Instance()
+
+	repository := &remoteConfigRepository{
+		baseRepository: &baseRepository{
+			db:             db,
+			collection:     db.Client().Database(utils.GetVariable(consts.DB_NAME)).Collection(consts.COLLECTION_REMOTE_CONFIG),
+			collectionName: consts.COLLECTION_REMOTE_CONFIG,
+			log:            logger.Instance(),
+		},
+	}
+
+	// Remote configurations are always looked up by scope and name
+	repository.createIndex(bson.D{{Key: "scope", Value: 1}, {Key: "name", Value: 1}})
+
+	return repository
+}
+
+// Find all remote configurations given a certain query
+func (r *remoteConfigRepository) Find(query interface{}) ([]types.RemoteConfig, error) {
+	return r.FindWithFilter(query, nil)
+}
+
+// FindWithFilter returns all remote configurations given a certain query and options
+func (r *remoteConfigRepository) FindWithFilter(query interface{}, options *options.FindOptions) ([]types.RemoteConfig, error) {
+	cursor, err := r.collection.Find(r.db.Context(), query, options)
+	if err != nil {
+		msg := fmt.Sprintf("failed to read data from collection: %s with query: %s (%s)", r.collectionName, query, err.Error())
+		r.log.Error(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	var data []types.RemoteConfig
+	defer cursor.Close(r.db.Context())
+	for cursor.Next(r.db.Context()) {
+		var record types.RemoteConfig
+		if err = cursor.Decode(&record); err != nil {
+			r.log.Warningf("failed to read record on %s with query: %s", r.collectionName, query)
+		}
+		data = append(data, record)
+	}
+
+	return data, nil
+}
+
+// Upsert replaces the remote configuration with the same scope and name, inserting it if it does not exist
+func (r *remoteConfigRepository) Upsert(data *types.RemoteConfig) error {
+	_, err := r.collection.ReplaceOne(r.db.Context(),
+		bson.M{"scope": data.Scope, "name": data.Name}, data,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		msg := fmt.Sprintf("failed to upsert data into collection: %s", r.collectionName)
+		r.log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
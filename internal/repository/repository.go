@@ -4,6 +4,7 @@ import (
 	"github.com/PR-Developers/server-health-monitor/internal/database"
 	"github.com/PR-Developers/server-health-monitor/internal/logger"
 	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,6 +15,7 @@ type IHealthRepository interface {
 	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Health, error)
 	// FindOne(where ...interface{}) (types.Health, error)
 	Insert(data *types.Health) (string, error)
+	InsertMany(data []types.Health) error
 	// Update(value interface{}) ([]types.Health, error)
 	// Delete(value interface{}) (types.Health, error)
 }
@@ -26,9 +28,98 @@ type IHostRepository interface {
 	UpdateByID(data *types.Host) error
 }
 
+// IProcessRepository is an interface which provides method signatures for a process snapshot repository
+type IProcessRepository interface {
+	Find(query interface{}) ([]types.ProcessSnapshot, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.ProcessSnapshot, error)
+	Insert(data *types.ProcessSnapshot) (string, error)
+}
+
+// ICheckRepository is an interface which provides method signatures for a check result repository
+type ICheckRepository interface {
+	Find(query interface{}) ([]types.CheckResult, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.CheckResult, error)
+	Insert(data *types.CheckResult) (string, error)
+}
+
+// IProbeRepository is an interface which provides method signatures for a probe result repository
+type IProbeRepository interface {
+	Find(query interface{}) ([]types.ProbeResult, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.ProbeResult, error)
+	Insert(data *types.ProbeResult) (string, error)
+}
+
+// ICertificateRepository is an interface which provides method signatures for a certificate repository
+type ICertificateRepository interface {
+	Find(query interface{}) ([]types.Certificate, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Certificate, error)
+	ReplaceForAgent(agentID string, data []types.Certificate) error
+}
+
+// IHostHistoryRepository is an interface which provides method signatures for a host inventory change repository
+type IHostHistoryRepository interface {
+	Find(query interface{}) ([]types.HostHistory, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.HostHistory, error)
+	Insert(data *types.HostHistory) (string, error)
+}
+
+// IRebootRepository is an interface which provides method signatures for a host reboot repository
+type IRebootRepository interface {
+	Find(query interface{}) ([]types.Reboot, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Reboot, error)
+	InsertIfNotExists(data *types.Reboot) (bool, error)
+}
+
+// IEventRepository is an interface which provides method signatures for a log event repository
+type IEventRepository interface {
+	Find(query interface{}) ([]types.Event, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.Event, error)
+	Insert(data *types.Event) (string, error)
+}
+
+// IRemoteConfigRepository is an interface which provides method signatures for a remote agent configuration repository
+type IRemoteConfigRepository interface {
+	Find(query interface{}) ([]types.RemoteConfig, error)
+	FindWithFilter(query interface{}, options *options.FindOptions) ([]types.RemoteConfig, error)
+	Upsert(data *types.RemoteConfig) error
+}
+
+// IEnrollmentTokenRepository is an interface which provides method signatures for an enrollment token repository
+type IEnrollmentTokenRepository interface {
+	Insert(data *types.EnrollmentToken) (string, error)
+	Use(hash string, agentID string, now int64) (bool, error)
+}
+
+// IAgentRepository is an interface which provides method signatures for an enrolled agent repository
+type IAgentRepository interface {
+	Find(query interface{}) ([]types.Agent, error)
+	Upsert(data *types.Agent) error
+	InsertIfNotExists(data *types.Agent) error
+	Revoke(agentID string) (bool, error)
+}
+
 type baseRepository struct {
 	db             database.Database
 	collection     *mongo.Collection
 	collectionName string // collection.Name() is an alternative but this is a static name so no need to query it
 	log            logger.Logger
 }
+
+// createIndex creates an index on the collection if it does not already exist
+func (r *baseRepository) createIndex(keys bson.D) {
+	r.createIndexModel(mongo.IndexModel{Keys: keys})
+}
+
+// createUniqueIndex creates an index on the collection which rejects documents with duplicate keys
+// if it does not already exist
+func (r *baseRepository) createUniqueIndex(keys bson.D) {
+	r.createIndexModel(mongo.IndexModel{Keys: keys, Options: options.Index().SetUnique(true)})
+}
+
+// createIndexModel creates the index described by model on the collection
+func (r *baseRepository) createIndexModel(model mongo.IndexModel) {
+	_, err := r.collection.Indexes().CreateOne(r.db.Context(), model)
+	if err != nil {
+		r.log.Warningf("failed to create index on collection: %s (%s)", r.collectionName, err.Error())
+	}
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type agentService struct {
+	enrollmentTokenRepository repository.IEnrollmentTokenRepository
+	agentRepository           repository.IAgentRepository
+	log                       logger.Logger
+}
+
+var (
+	_ IAgentService = (*agentService)(nil)
+)
+
+// NewAgentService returns an instanced agent enrollment service
+func NewAgentService(enrollmentTokenRepository repository.IEnrollmentTokenRepository, agentRepository repository.IAgentRepository) IAgentService {
+	return &agentService{
+		enrollmentTokenRepository: enrollmentTokenRepository,
+		agentRepository:           agentRepository,
+		log:                       logger.Instance(),
+	}
+}
+
+// CreateEnrollmentToken creates a token agents can enroll with. The token is only returned here
+func (s *agentService) CreateEnrollmentToken(requestID string, data *types.EnrollmentToken) types.EnrollmentTokenResponse {
+	s.log.Infof("attemping to create enrollment token - Request ID: %s", requestID)
+
+	if data.MaxUses < 0 || data.ExpireTime < 0 {
+		return types.EnrollmentTokenResponse{
+			Data:       []types.EnrollmentToken{},
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Sprintf("enrollment token max uses and expire time can not be negative - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	token, err := generateSecret()
+	if err != nil {
+		return types.EnrollmentTokenResponse{
+			Data:       []types.EnrollmentToken{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to create enrollment token - Request ID %s", requestID),
+			Success:    false,
+		}
+	}
+
+	data.ID = primitive.NewObjectID()
+	data.Token = token
+	data.Hash = hashSecret(token)
+	data.Uses = 0
+	data.CreateTime = time.Now().UTC().UnixNano()
+	if data.MaxUses == 0 {
+		data.MaxUses = 1
+	}
+
+	if _, err = s.enrollmentTokenRepository.Insert(data); err != nil {
+		return types.EnrollmentTokenResponse{
+			Data:       []types.EnrollmentToken{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to create enrollment token - Request ID %s", requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully created enrollment token - Request ID: %s", requestID)
+
+	return types.EnrollmentTokenResponse{
+		Data:       []types.EnrollmentToken{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// Enroll exchanges an enrollment token for a new secret of the agent. An agent which is already enrolled or revoked
+// can only enroll again with a token an admin created for it, which replaces the previous secret and lifts a revocation
+func (s *agentService) Enroll(requestID string, agentID string, token string) types.EnrollmentResponse {
+	s.log.Infof("attemping to enroll agent: %s - Request ID: %s", agentID, requestID)
+
+	if agentID == "" || token == "" {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Sprintf("enrolling requires an agent ID and a token - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	existing, err := s.agentRepository.Find(bson.M{"agentID": agentID})
+	if err != nil {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to enroll agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	hash := hashSecret(token)
+	now := time.Now().UTC().UnixNano()
+	valid := false
+	if len(existing) == 0 {
+		valid, err = s.enrollmentTokenRepository.Use(hash, "", now)
+	}
+	if err == nil && !valid {
+		valid, err = s.enrollmentTokenRepository.Use(hash, agentID, now)
+	}
+	if err != nil {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to enroll agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+	if !valid && len(existing) > 0 {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusConflict,
+			Error: fmt.Sprintf("agent: %s is already enrolled or revoked, enrolling it again requires a valid enrollment token created for it - Request ID: %s",
+				agentID, requestID),
+			Success: false,
+		}
+	}
+	if !valid {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusUnauthorized,
+			Error:      fmt.Sprintf("enrollment token is invalid, used up or expired - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to enroll agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	agent := &types.Agent{
+		ID:         primitive.NewObjectID(),
+		AgentID:    agentID,
+		SecretHash: hashSecret(secret),
+		EnrollTime: now,
+	}
+	if len(existing) > 0 {
+		agent.ID = existing[0].ID
+	}
+	if err = s.agentRepository.Upsert(agent); err != nil {
+		return types.EnrollmentResponse{
+			Data:       []types.Enrollment{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to enroll agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully enrolled agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.EnrollmentResponse{
+		Data:       []types.Enrollment{{AgentID: agentID, Secret: secret}},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// Authenticate checks the secret of an agent, failing for agents which are not enrolled or are revoked
+func (s *agentService) Authenticate(requestID string, agentID string, secret string) types.AgentResponse {
+	if agentID == "" || secret == "" {
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusUnauthorized,
+			Error:      fmt.Sprintf("agent ID and secret are required - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	data, err := s.agentRepository.Find(bson.M{"agentID": agentID})
+	if err != nil {
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to authenticate agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	if len(data) == 0 || data[0].Revoked ||
+		subtle.ConstantTimeCompare([]byte(data[0].SecretHash), []byte(hashSecret(secret))) != 1 {
+		s.log.Warningf("rejected agent: %s - Request ID: %s", agentID, requestID)
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusUnauthorized,
+			Error:      fmt.Sprintf("agent: %s is not enrolled, revoked or sent the wrong secret - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	return types.AgentResponse{
+		Data:       data[:1],
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AuthenticateCertificate checks that a verified client certificate identifies the agent. Agents which
+// present a certificate do not need to be enrolled, they are recorded the first time they authenticate
+// so they can still be revoked
+func (s *agentService) AuthenticateCertificate(requestID string, agentID string, certificate *x509.Certificate) types.AgentResponse {
+	if !utils.CertificateMatchesAgent(certificate, agentID) {
+		s.log.Warningf("rejected certificate of %s for agent: %s - Request ID: %s", certificate.Subject.String(), agentID, requestID)
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusUnauthorized,
+			Error:      fmt.Sprintf("client certificate does not identify agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	data, err := s.agentRepository.Find(bson.M{"agentID": agentID})
+	if err != nil {
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to authenticate agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+	if len(data) == 0 {
+		agent := types.Agent{
+			ID:         primitive.NewObjectID(),
+			AgentID:    agentID,
+			EnrollTime: time.Now().UTC().UnixNano(),
+		}
+		if err = s.agentRepository.InsertIfNotExists(&agent); err != nil {
+			return types.AgentResponse{
+				Data:       []types.Agent{},
+				StatusCode: http.StatusInternalServerError,
+				Error:      fmt.Sprintf("failed to authenticate agent: %s - Request ID: %s", agentID, requestID),
+				Success:    false,
+			}
+		}
+		s.log.Infof("recorded agent: %s which authenticated with a certificate - Request ID: %s", agentID, requestID)
+		data = []types.Agent{agent}
+	}
+	if data[0].Revoked {
+		s.log.Warningf("rejected agent: %s - Request ID: %s", agentID, requestID)
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusUnauthorized,
+			Error:      fmt.Sprintf("agent: %s has been revoked - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	return types.AgentResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// RevokeAgent stops an enrolled agent from authenticating until it enrolls again
+func (s *agentService) RevokeAgent(requestID string, agentID string) types.AgentResponse {
+	s.log.Infof("attemping to revoke agent: %s - Request ID: %s", agentID, requestID)
+
+	found, err := s.agentRepository.Revoke(agentID)
+	if err != nil {
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to revoke agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+	if !found {
+		return types.AgentResponse{
+			Data:       []types.Agent{},
+			StatusCode: http.StatusNotFound,
+			Error:      fmt.Sprintf("agent: %s is not enrolled - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully revoked agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.AgentResponse{
+		Data:       []types.Agent{},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// generateSecret returns a random hex encoded 256 bit secret
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// hashSecret returns the hex encoded SHA-256 hash of a secret. Secrets are random so no salt is needed
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
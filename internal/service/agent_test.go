@@ -0,0 +1,168 @@
+package service
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockery --dir=../ -r --name IEnrollmentTokenRepository
+//go:generate mockery --dir=../ -r --name IAgentRepository
+
+func getInitializedAgentService() (IAgentService, *mock.Mock, *mock.Mock) {
+	enrollmentTokenRepo := new(mocks.IEnrollmentTokenRepository)
+	agentRepo := new(mocks.IAgentRepository)
+	return NewAgentService(enrollmentTokenRepo, agentRepo), &enrollmentTokenRepo.Mock, &agentRepo.Mock
+}
+
+func TestAgent_CreateEnrollmentToken_StoresOnlyTheHash(t *testing.T) {
+	agentService, enrollmentTokenMock, _ := getInitializedAgentService()
+	enrollmentTokenMock.On("Insert", mock.Anything).Return("1", nil)
+
+	res := agentService.CreateEnrollmentToken("1", &types.EnrollmentToken{})
+
+	assert.True(t, res.Success)
+	token := res.Data[0]
+	assert.Len(t, token.Token, 64)
+	assert.Equal(t, hashSecret(token.Token), token.Hash)
+	assert.Equal(t, 1, token.MaxUses)
+	assert.NotZero(t, token.CreateTime)
+
+	enrollmentTokenMock.AssertExpectations(t)
+}
+
+func TestAgent_CreateEnrollmentToken_RejectsNegativeValues(t *testing.T) {
+	agentService, enrollmentTokenMock, _ := getInitializedAgentService()
+
+	res := agentService.CreateEnrollmentToken("1", &types.EnrollmentToken{MaxUses: -1})
+
+	assert.False(t, res.Success)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	enrollmentTokenMock.AssertNotCalled(t, "Insert", mock.Anything)
+}
+
+func TestAgent_Enroll_ReturnsSecret(t *testing.T) {
+	agentService, enrollmentTokenMock, agentMock := getInitializedAgentService()
+	enrollmentTokenMock.On("Use", hashSecret("token"), "", mock.Anything).Return(true, nil)
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{}, nil)
+	agentMock.On("Upsert", mock.MatchedBy(func(agent *types.Agent) bool {
+		return agent.AgentID == "1" && !agent.Revoked && agent.EnrollTime > 0
+	})).Return(nil)
+
+	res := agentService.Enroll("1", "1", "token")
+
+	assert.True(t, res.Success)
+	assert.Equal(t, "1", res.Data[0].AgentID)
+	assert.Len(t, res.Data[0].Secret, 64)
+	upserted := agentMock.Calls[1].Arguments.Get(0).(*types.Agent)
+	assert.Equal(t, hashSecret(res.Data[0].Secret), upserted.SecretHash)
+
+	enrollmentTokenMock.AssertExpectations(t)
+	agentMock.AssertExpectations(t)
+}
+
+func TestAgent_Enroll_RejectsInvalidToken(t *testing.T) {
+	agentService, enrollmentTokenMock, agentMock := getInitializedAgentService()
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{}, nil)
+	enrollmentTokenMock.On("Use", hashSecret("token"), "", mock.Anything).Return(false, nil).Once()
+	enrollmentTokenMock.On("Use", hashSecret("token"), "1", mock.Anything).Return(false, nil).Once()
+	enrollmentTokenMock.On("Use", hashSecret("token"), "", mock.Anything).Return(false, fmt.Errorf("failed")).Once()
+
+	res := agentService.Enroll("1", "1", "token")
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res = agentService.Enroll("1", "1", "token")
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	res = agentService.Enroll("1", "", "token")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	enrollmentTokenMock.AssertExpectations(t)
+	agentMock.AssertNotCalled(t, "Upsert", mock.Anything)
+}
+
+func TestAgent_Enroll_RequiresTokenCreatedForEnrolledAgent(t *testing.T) {
+	agentService, enrollmentTokenMock, agentMock := getInitializedAgentService()
+	existing := types.Agent{ID: primitive.NewObjectID(), AgentID: "1", SecretHash: hashSecret("secret"), Revoked: true}
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{existing}, nil)
+	enrollmentTokenMock.On("Use", hashSecret("token"), "1", mock.Anything).Return(false, nil).Once()
+	enrollmentTokenMock.On("Use", hashSecret("agent token"), "1", mock.Anything).Return(true, nil).Once()
+	agentMock.On("Upsert", mock.MatchedBy(func(agent *types.Agent) bool {
+		return agent.ID == existing.ID && !agent.Revoked && agent.SecretHash != existing.SecretHash
+	})).Return(nil)
+
+	res := agentService.Enroll("1", "1", "token")
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	assert.False(t, res.Success)
+	agentMock.AssertNotCalled(t, "Upsert", mock.Anything)
+
+	res = agentService.Enroll("1", "1", "agent token")
+	assert.True(t, res.Success)
+
+	enrollmentTokenMock.AssertExpectations(t)
+	agentMock.AssertExpectations(t)
+}
+
+func TestAgent_Authenticate_ChecksSecret(t *testing.T) {
+	agentService, _, agentMock := getInitializedAgentService()
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{{AgentID: "1", SecretHash: hashSecret("secret")}}, nil)
+	agentMock.On("Find", bson.M{"agentID": "2"}).Return([]types.Agent{{AgentID: "2", SecretHash: hashSecret("secret"), Revoked: true}}, nil)
+	agentMock.On("Find", bson.M{"agentID": "3"}).Return([]types.Agent{}, nil)
+
+	assert.True(t, agentService.Authenticate("1", "1", "secret").Success)
+	assert.Equal(t, http.StatusUnauthorized, agentService.Authenticate("1", "1", "wrong").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, agentService.Authenticate("1", "1", "").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, agentService.Authenticate("1", "2", "secret").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, agentService.Authenticate("1", "3", "secret").StatusCode)
+
+	agentMock.AssertExpectations(t)
+}
+
+func TestAgent_AuthenticateCertificate_ChecksIdentity(t *testing.T) {
+	agentService, _, agentMock := getInitializedAgentService()
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{{AgentID: "1"}}, nil)
+	agentMock.On("Find", bson.M{"agentID": "2"}).Return([]types.Agent{{AgentID: "2", Revoked: true}}, nil)
+
+	assert.True(t, agentService.AuthenticateCertificate("1", "1", &x509.Certificate{Subject: pkix.Name{CommonName: "1"}}).Success)
+	assert.Equal(t, http.StatusUnauthorized, agentService.AuthenticateCertificate("1", "1", &x509.Certificate{Subject: pkix.Name{CommonName: "2"}}).StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, agentService.AuthenticateCertificate("1", "2", &x509.Certificate{Subject: pkix.Name{CommonName: "2"}}).StatusCode)
+
+	agentMock.AssertExpectations(t)
+}
+
+func TestAgent_AuthenticateCertificate_RecordsAgentSoItCanBeRevoked(t *testing.T) {
+	agentService, _, agentMock := getInitializedAgentService()
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "1"}}
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{}, nil).Once()
+	agentMock.On("InsertIfNotExists", mock.MatchedBy(func(agent *types.Agent) bool {
+		return agent.AgentID == "1" && agent.SecretHash == "" && !agent.Revoked
+	})).Return(nil).Once()
+	agentMock.On("Revoke", "1").Return(true, nil)
+	agentMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Agent{{AgentID: "1", Revoked: true}}, nil)
+
+	assert.True(t, agentService.AuthenticateCertificate("1", "1", certificate).Success)
+	assert.True(t, agentService.RevokeAgent("1", "1").Success)
+	assert.Equal(t, http.StatusUnauthorized, agentService.AuthenticateCertificate("1", "1", certificate).StatusCode)
+
+	agentMock.AssertExpectations(t)
+}
+
+func TestAgent_RevokeAgent_ReturnsNotFound(t *testing.T) {
+	agentService, _, agentMock := getInitializedAgentService()
+	agentMock.On("Revoke", "1").Return(true, nil)
+	agentMock.On("Revoke", "2").Return(false, nil)
+
+	assert.True(t, agentService.RevokeAgent("1", "1").Success)
+	assert.Equal(t, http.StatusNotFound, agentService.RevokeAgent("1", "2").StatusCode)
+
+	agentMock.AssertExpectations(t)
+}
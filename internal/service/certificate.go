@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+	"github.com/PR-Developers/server-health-monitor/internal/wrapper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// apiAgentID is the agent id used for the API's own serving certificate
+	apiAgentID = "api"
+)
+
+type certificateService struct {
+	certificateRepository repository.ICertificateRepository
+	osWrapper             wrapper.OperatingSystem
+	log                   logger.Logger
+}
+
+var (
+	_ ICertificateService = (*certificateService)(nil)
+)
+
+// NewCertificateService returns an instanced certificate service
+func NewCertificateService(certificateRepository repository.ICertificateRepository, osWrapper wrapper.OperatingSystem) ICertificateService {
+	return &certificateService{
+		certificateRepository: certificateRepository,
+		osWrapper:             osWrapper,
+		log:                   logger.Instance(),
+	}
+}
+
+// GetExpiringCertificates returns the soonest expiring certificates across all agents, including the API's own certificate
+func (s *certificateService) GetExpiringCertificates(requestID string, limit int64) types.CertificateResponse {
+	s.log.Info("attemping to get expiring certificates - Request ID: " + requestID)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "notAfter", Value: 1}})
+	options.SetLimit(limit)
+
+	data, err := s.certificateRepository.FindWithFilter(bson.M{"notAfter": bson.M{"$gt": 0}}, options)
+	if err != nil {
+		return types.CertificateResponse{
+			Data:       []types.Certificate{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get expiring certificates - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	data = append(data, s.getAPICertificates()...)
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].NotAfter < data[j].NotAfter
+	})
+	if int64(len(data)) > limit {
+		data = data[:limit]
+	}
+
+	s.log.Info("successfully got expiring certificates - Request ID: " + requestID)
+
+	return types.CertificateResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// GetCertificatesByAgentID returns all certificates (soonest expiring first) reported by a given agent
+func (s *certificateService) GetCertificatesByAgentID(requestID string, agentID string) types.CertificateResponse {
+	s.log.Infof("attemping to get certificates for agent: %s - Request ID: %s", agentID, requestID)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "notAfter", Value: 1}})
+
+	data, err := s.certificateRepository.FindWithFilter(bson.M{"agentID": agentID}, options)
+	if err != nil {
+		return types.CertificateResponse{
+			Data:       []types.Certificate{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get certificates for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got certificates for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.CertificateResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AddCertificates replaces the certificates reported by a given agent, data has to contain every source of the agent
+func (s *certificateService) AddCertificates(requestID string, agentID string, data []types.Certificate) types.CertificateResponse {
+	s.log.Infof("attemping to insert certificates for agent: %s - Request ID: %s", agentID, requestID)
+
+	now := time.Now().UTC().UnixNano()
+	for i := range data {
+		data[i].AgentID = agentID
+		data[i].ID = primitive.NewObjectID()
+		data[i].CreateTime = now
+	}
+
+	if err := s.certificateRepository.ReplaceForAgent(agentID, data); err != nil {
+		return types.CertificateResponse{
+			Data:       []types.Certificate{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert certificates for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully inserted certificates for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.CertificateResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// getAPICertificates returns the certificates the API is serving
+func (s *certificateService) getAPICertificates() []types.Certificate {
+	file := fmt.Sprintf("%s/%s", utils.GetVariable(consts.CERT_DIR), utils.GetVariable(consts.API_CERT))
+	data, err := s.osWrapper.ReadFile(file)
+	if err != nil {
+		s.log.Warningf("failed to read api certificate: %s (%s)", file, err.Error())
+		return []types.Certificate{}
+	}
+
+	certificates, err := utils.ParseCertificates(data, file)
+	if err != nil {
+		s.log.Warningf("failed to parse api certificate: %s (%s)", file, err.Error())
+		return []types.Certificate{}
+	}
+	for i := range certificates {
+		certificates[i].AgentID = apiAgentID
+	}
+	return certificates
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate mockery --dir=../ -r --name ICertificateRepository
+//go:generate mockery --dir=../ -r --name OperatingSystem
+
+var (
+	certificateData []types.Certificate = []types.Certificate{
+		{AgentID: "1", Source: "/etc/ssl/a.crt", Subject: "CN=a", NotAfter: 10},
+		{AgentID: "2", Source: "example.com:443", Subject: "CN=example.com", NotAfter: 30},
+	}
+)
+
+func getInitializedCertificateService() (ICertificateService, *mock.Mock, *mock.Mock) {
+	certificateRepo := new(mocks.ICertificateRepository)
+	osWrapper := new(mocks.OperatingSystem)
+	return NewCertificateService(certificateRepo, osWrapper), &certificateRepo.Mock, &osWrapper.Mock
+}
+
+func createAPICertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificate_GetExpiringCertificates_ReturnsExpectedData(t *testing.T) {
+	certificateService, certificateMock, osMock := getInitializedCertificateService()
+	certificateMock.On("FindWithFilter", bson.M{"notAfter": bson.M{"$gt": 0}}, mock.Anything).Return(certificateData, nil)
+	osMock.On("ReadFile", mock.Anything).Return(nil, fmt.Errorf("file not found"))
+
+	res := certificateService.GetExpiringCertificates("1", 10)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, certificateData, res.Data)
+
+	certificateMock.AssertExpectations(t)
+}
+
+func TestCertificate_GetExpiringCertificates_IncludesAPICertificate(t *testing.T) {
+	certificateService, certificateMock, osMock := getInitializedCertificateService()
+	certificateMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Certificate{
+		{AgentID: "1", Subject: "CN=a", NotAfter: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()},
+	}, nil)
+	osMock.On("ReadFile", mock.Anything).Return(createAPICertificate(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+
+	res := certificateService.GetExpiringCertificates("1", 1)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+	assert.Equal(t, "api", res.Data[0].AgentID)
+	assert.Equal(t, "CN=api", res.Data[0].Subject)
+
+	certificateMock.AssertExpectations(t)
+	osMock.AssertExpectations(t)
+}
+
+func TestCertificate_GetExpiringCertificates_HandlesError(t *testing.T) {
+	certificateService, certificateMock, _ := getInitializedCertificateService()
+	certificateMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := certificateService.GetExpiringCertificates("1", 10)
+
+	assert.Equal(t, []types.Certificate{}, res.Data)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get expiring certificates - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+
+	certificateMock.AssertExpectations(t)
+}
+
+func TestCertificate_GetCertificatesByAgentID_ReturnsExpectedData(t *testing.T) {
+	certificateService, certificateMock, _ := getInitializedCertificateService()
+	certificateMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.Anything).Return(certificateData[:1], nil)
+
+	res := certificateService.GetCertificatesByAgentID("1", "1")
+
+	assert.True(t, res.Success)
+	assert.Equal(t, certificateData[:1], res.Data)
+
+	certificateMock.AssertExpectations(t)
+}
+
+func TestCertificate_AddCertificates_ReplacesAllSources(t *testing.T) {
+	certificateService, certificateMock, _ := getInitializedCertificateService()
+	certificateMock.On("ReplaceForAgent", "1", mock.MatchedBy(func(data []types.Certificate) bool { return len(data) == 3 })).Return(nil)
+
+	res := certificateService.AddCertificates("1", "1", []types.Certificate{
+		{Source: "a.crt", Subject: "CN=leaf"},
+		{Source: "b.crt", Subject: "CN=b"},
+		{Source: "a.crt", Subject: "CN=ca"},
+	})
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 3, len(res.Data))
+	assert.Equal(t, "1", res.Data[0].AgentID)
+	assert.NotEmpty(t, res.Data[0].ID)
+
+	certificateMock.AssertExpectations(t)
+}
+
+func TestCertificate_AddCertificates_HandlesError(t *testing.T) {
+	certificateService, certificateMock, _ := getInitializedCertificateService()
+	certificateMock.On("ReplaceForAgent", mock.Anything, mock.Anything).Return(fmt.Errorf("failed to insert data into DB"))
+
+	res := certificateService.AddCertificates("1", "2", []types.Certificate{{Source: "a.crt"}})
+
+	assert.Equal(t, []types.Certificate{}, res.Data)
+	assert.Equal(t, "failed to insert certificates for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	certificateMock.AssertExpectations(t)
+}
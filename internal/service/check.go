@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type checkService struct {
+	checkRepository repository.ICheckRepository
+	log             logger.Logger
+}
+
+var (
+	_ ICheckService = (*checkService)(nil)
+)
+
+// NewCheckService returns an instanced check result service
+func NewCheckService(checkRepository repository.ICheckRepository) ICheckService {
+	return &checkService{
+		checkRepository: checkRepository,
+		log:             logger.Instance(),
+	}
+}
+
+// GetChecksByAgentID returns the latest check results (newest first) for a given agent,
+// optionally between from and to (inclusive)
+func (s *checkService) GetChecksByAgentID(requestID string, agentID string, from int64, to int64) types.CheckResponse {
+	s.log.Infof("attemping to get check results for agent: %s - Request ID: %s", agentID, requestID)
+
+	query := bson.M{"agentID": agentID}
+	timeRangeFilter(query, from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	options.SetLimit(100)
+
+	data, err := s.checkRepository.FindWithFilter(query, options)
+	if err != nil {
+		return types.CheckResponse{
+			Data:       []types.CheckResult{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get check results for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got check results for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.CheckResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AddCheck inserts a new check result for a given agent
+func (s *checkService) AddCheck(requestID string, agentID string, data *types.CheckResult) types.CheckResponse {
+	s.log.Infof("attemping to insert check result for agent: %s - Request ID: %s", agentID, requestID)
+
+	data.AgentID = agentID
+	data.ID = primitive.NewObjectID()
+	data.CreateTime = time.Now().UTC().UnixNano()
+
+	_, err := s.checkRepository.Insert(data)
+
+	if err != nil {
+		return types.CheckResponse{
+			Data:       []types.CheckResult{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert check result for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully inserted check result for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.CheckResponse{
+		Data:       []types.CheckResult{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate mockery --dir=../ -r --name ICheckRepository
+
+func getInitializedCheckService() (ICheckService, *mock.Mock) {
+	checkRepo := new(mocks.ICheckRepository)
+	return NewCheckService(checkRepo), &checkRepo.Mock
+}
+
+func TestCheck_GetChecksByAgentID_ReturnsExpectedData(t *testing.T) {
+	checkService, checkMock := getInitializedCheckService()
+	checkMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.Anything).Return([]types.CheckResult{
+		{AgentID: "1", Name: "disk", Status: "OK"},
+	}, nil)
+
+	res := checkService.GetChecksByAgentID("1", "1", 0, 0)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+	assert.Equal(t, "disk", res.Data[0].Name)
+
+	checkMock.AssertExpectations(t)
+}
+
+func TestCheck_GetChecksByAgentID_FiltersByTimeRange(t *testing.T) {
+	checkService, checkMock := getInitializedCheckService()
+	checkMock.On("FindWithFilter", bson.M{
+		"agentID":    "1",
+		"createTime": bson.M{"$gte": int64(5)},
+	}, mock.Anything).Return([]types.CheckResult{}, nil)
+
+	res := checkService.GetChecksByAgentID("1", "1", 5, 0)
+
+	assert.True(t, res.Success)
+
+	checkMock.AssertExpectations(t)
+}
+
+func TestCheck_GetChecksByAgentID_HandlesError(t *testing.T) {
+	checkService, checkMock := getInitializedCheckService()
+	checkMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := checkService.GetChecksByAgentID("1", "1", 0, 0)
+
+	assert.Equal(t, []types.CheckResult{}, res.Data)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get check results for agent: 1 - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+
+	checkMock.AssertExpectations(t)
+}
+
+func TestCheck_AddCheck_AddsExpectedData(t *testing.T) {
+	checkService, checkMock := getInitializedCheckService()
+	result := &types.CheckResult{Name: "disk", Status: "WARNING", ExitCode: 1, CheckTime: 10}
+	checkMock.On("Insert", result).Return("1234567", nil)
+
+	res := checkService.AddCheck("1", "1", result)
+
+	assert.True(t, res.Success)
+	assert.NotEmpty(t, res.Data[0].ID)
+	assert.Equal(t, "1", res.Data[0].AgentID)
+	assert.Equal(t, int64(10), res.Data[0].CheckTime)
+
+	checkMock.AssertExpectations(t)
+}
+
+func TestCheck_AddCheck_HandlesError(t *testing.T) {
+	checkService, checkMock := getInitializedCheckService()
+	result := &types.CheckResult{}
+	checkMock.On("Insert", result).Return("", fmt.Errorf("failed to insert data into DB"))
+
+	res := checkService.AddCheck("1", "2", result)
+
+	assert.Equal(t, []types.CheckResult{}, res.Data)
+	assert.Equal(t, "failed to insert check result for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	checkMock.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eventService struct {
+	eventRepository repository.IEventRepository
+	log             logger.Logger
+}
+
+var (
+	_ IEventService = (*eventService)(nil)
+)
+
+// NewEventService returns an instanced log event service
+func NewEventService(eventRepository repository.IEventRepository) IEventService {
+	return &eventService{
+		eventRepository: eventRepository,
+		log:             logger.Instance(),
+	}
+}
+
+// GetEventsByAgentID returns the latest log events (newest first by the time the agent saw them) for a given agent,
+// optionally between from and to (inclusive)
+func (s *eventService) GetEventsByAgentID(requestID string, agentID string, from int64, to int64) types.EventResponse {
+	s.log.Infof("attemping to get log events for agent: %s - Request ID: %s", agentID, requestID)
+
+	query := bson.M{"agentID": agentID}
+	fieldRangeFilter(query, "eventTime", from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "eventTime", Value: -1}})
+	options.SetLimit(100)
+
+	data, err := s.eventRepository.FindWithFilter(query, options)
+	if err != nil {
+		return types.EventResponse{
+			Data:       []types.Event{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get log events for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got log events for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.EventResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AddEvent inserts a new log event for a given agent
+func (s *eventService) AddEvent(requestID string, agentID string, data *types.Event) types.EventResponse {
+	s.log.Infof("attemping to insert log event for agent: %s - Request ID: %s", agentID, requestID)
+
+	data.AgentID = agentID
+	data.ID = primitive.NewObjectID()
+	data.CreateTime = time.Now().UTC().UnixNano()
+
+	_, err := s.eventRepository.Insert(data)
+
+	if err != nil {
+		return types.EventResponse{
+			Data:       []types.Event{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert log event for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully inserted log event for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.EventResponse{
+		Data:       []types.Event{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//go:generate mockery --dir=../ -r --name IEventRepository
+
+func getInitializedEventService() (IEventService, *mock.Mock) {
+	eventRepo := new(mocks.IEventRepository)
+	return NewEventService(eventRepo), &eventRepo.Mock
+}
+
+func TestEvent_GetEventsByAgentID_ReturnsExpectedData(t *testing.T) {
+	eventService, eventMock := getInitializedEventService()
+	eventMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.Anything).Return([]types.Event{
+		{AgentID: "1", Source: "/var/log/syslog", Rule: "oom", Count: 3},
+	}, nil)
+
+	res := eventService.GetEventsByAgentID("1", "1", 0, 0)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+	assert.Equal(t, "oom", res.Data[0].Rule)
+
+	eventMock.AssertExpectations(t)
+}
+
+func TestEvent_GetEventsByAgentID_FiltersAndSortsByEventTime(t *testing.T) {
+	eventService, eventMock := getInitializedEventService()
+	eventMock.On("FindWithFilter", bson.M{
+		"agentID":   "1",
+		"eventTime": bson.M{"$gte": int64(5), "$lte": int64(20)},
+	}, mock.MatchedBy(func(o *options.FindOptions) bool {
+		return reflect.DeepEqual(o.Sort, bson.D{primitive.E{Key: "eventTime", Value: -1}})
+	})).Return([]types.Event{
+		{AgentID: "1", Source: "/var/log/syslog", Rule: "oom", Count: 3, EventTime: 10},
+	}, nil)
+
+	res := eventService.GetEventsByAgentID("1", "1", 5, 20)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+	assert.Equal(t, "oom", res.Data[0].Rule)
+
+	eventMock.AssertExpectations(t)
+}
+
+func TestEvent_GetEventsByAgentID_HandlesError(t *testing.T) {
+	eventService, eventMock := getInitializedEventService()
+	eventMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := eventService.GetEventsByAgentID("1", "1", 0, 0)
+
+	assert.Equal(t, []types.Event{}, res.Data)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get log events for agent: 1 - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+
+	eventMock.AssertExpectations(t)
+}
+
+func TestEvent_AddEvent_AddsExpectedData(t *testing.T) {
+	eventService, eventMock := getInitializedEventService()
+	event := &types.Event{Source: "/var/log/syslog", Rule: "segfault", Count: 1, EventTime: 10}
+	eventMock.On("Insert", event).Return("1234567", nil)
+
+	res := eventService.AddEvent("1", "1", event)
+
+	assert.True(t, res.Success)
+	assert.NotEmpty(t, res.Data[0].ID)
+	assert.Equal(t, "1", res.Data[0].AgentID)
+	assert.Equal(t, int64(10), res.Data[0].EventTime)
+
+	eventMock.AssertExpectations(t)
+}
+
+func TestEvent_AddEvent_HandlesError(t *testing.T) {
+	eventService, eventMock := getInitializedEventService()
+	event := &types.Event{}
+	eventMock.On("Insert", event).Return("", fmt.Errorf("failed to insert data into DB"))
+
+	res := eventService.AddEvent("1", "2", event)
+
+	assert.Equal(t, []types.Event{}, res.Data)
+	assert.Equal(t, "failed to insert log event for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	eventMock.AssertExpectations(t)
+}
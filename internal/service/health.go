@@ -18,6 +18,7 @@ import (
 type healthService struct {
 	healthRepository repository.IHealthRepository
 	hostRepository   repository.IHostRepository
+	rebootService    IRebootService
 	log              logger.Logger
 }
 
@@ -26,10 +27,11 @@ var (
 )
 
 // NewHealthService returns an instanced health service
-func NewHealthService(healthRepository repository.IHealthRepository, hostRepository repository.IHostRepository) IHealthService {
+func NewHealthService(healthRepository repository.IHealthRepository, hostRepository repository.IHostRepository, rebootService IRebootService) IHealthService {
 	return &healthService{
 		healthRepository: healthRepository,
 		hostRepository:   hostRepository,
+		rebootService:    rebootService,
 		log:              logger.Instance(),
 	}
 }
@@ -83,10 +85,7 @@ func (s *healthService) GetHealthByAgentID(requestID, agentID string) types.Heal
 func (s *healthService) AddHealth(requestID string, agentID string, data *types.Health) types.HealthReponse {
 	s.log.Infof("attemping to insert health data for agent: %s - Request ID: %s", agentID, requestID)
 
-	data.AgentID = agentID
-	data.ID = primitive.NewObjectID()
-	now := time.Now().UTC().UnixNano()
-	data.CreateTime = now
+	s.prepareHealth(agentID, data, time.Now().UTC().UnixNano())
 
 	_, err := s.healthRepository.Insert(data)
 
@@ -100,6 +99,7 @@ func (s *healthService) AddHealth(requestID string, agentID string, data *types.
 	}
 
 	s.log.Infof("successfully inserted health data for agent: %s - Request ID: %s", agentID, requestID)
+	s.rebootService.DetectFromHealth(requestID, agentID, []types.Health{*data})
 
 	return types.HealthReponse{
 		Data:       []types.Health{*data},
@@ -108,7 +108,46 @@ func (s *healthService) AddHealth(requestID string, agentID string, data *types.
 	}
 }
 
-// GetLatestHealthDataByAgentID returns the latest health data since a givrm time for a given agent
+// AddHealthBatch inserts multiple health samples for a given agent at once
+func (s *healthService) AddHealthBatch(requestID string, agentID string, data []types.Health) types.HealthReponse {
+	s.log.Infof("attemping to insert %d health samples for agent: %s - Request ID: %s", len(data), agentID, requestID)
+
+	if len(data) == 0 {
+		return types.HealthReponse{
+			Data:       []types.Health{},
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Sprintf("no health data to insert for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	now := time.Now().UTC().UnixNano()
+	for i := range data {
+		s.prepareHealth(agentID, &data[i], now)
+	}
+
+	err := s.healthRepository.InsertMany(data)
+
+	if err != nil {
+		return types.HealthReponse{
+			Data:       []types.Health{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert data for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully inserted %d health samples for agent: %s - Request ID: %s", len(data), agentID, requestID)
+	s.rebootService.DetectFromHealth(requestID, agentID, data)
+
+	return types.HealthReponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// GetLatestHealthDataByAgentID returns the health data the api received since a given time for a given agent
 func (s *healthService) GetLatestHealthDataByAgentID(requestID string, agentID string, time int64) types.HealthReponse {
 	s.log.Infof("attemping to get health data for agent: %s - Request ID: %s", agentID, requestID)
 
@@ -119,7 +158,7 @@ func (s *healthService) GetLatestHealthDataByAgentID(requestID string, agentID s
 		"agentID": bson.M{"$eq": agentID},
 		"$and": []bson.M{
 			{
-				"createTime": bson.M{"$gt": time},
+				"receivedTime": bson.M{"$gt": time},
 			},
 		},
 	}, options)
@@ -146,7 +185,7 @@ func (s *healthService) GetLatestHealthDataByAgentID(requestID string, agentID s
 	}
 }
 
-// GetLatestHealthDataForAgents returns all health data for all agents since a given time for an agent
+// GetLatestHealthDataForAgents returns all health data and reboots for all agents since a given time
 func (s *healthService) GetLatestHealthDataForAgents(requestID string, since int64) types.HostReponse {
 	data, err := s.hostRepository.Find(bson.M{})
 
@@ -159,11 +198,21 @@ func (s *healthService) GetLatestHealthDataForAgents(requestID string, since int
 		}
 	}
 
+	// The reboots of every agent are fetched at once and grouped by agent
+	reboots := s.rebootService.GetReboots(requestID, since, 0)
+	rebootsByAgent := map[string][]types.Reboot{}
+	for _, reboot := range reboots.Data {
+		rebootsByAgent[reboot.AgentID] = append(rebootsByAgent[reboot.AgentID], reboot)
+	}
+
 	for i, host := range data {
 		res := s.GetLatestHealthDataByAgentID(requestID, host.AgentID, since)
 		if res.Success {
 			data[i].Health = res.Data
 		}
+		if reboots.Success {
+			data[i].Reboots = append([]types.Reboot{}, rebootsByAgent[host.AgentID]...)
+		}
 	}
 
 	return types.HostReponse{
@@ -182,3 +231,27 @@ func (s *healthService) GetHealthForAgentWithOptions(requestID string, agentID s
 	}
 	return data
 }
+
+// prepareHealth sets the fields of health data which are owned by the api
+func (s *healthService) prepareHealth(agentID string, data *types.Health, now int64) {
+	data.AgentID = agentID
+	data.ID = primitive.NewObjectID()
+	// Keep the time the agent sampled the data at as buffered data is sent long after it was collected,
+	// the time it was received at is used to find new data as it is not affected by batching or the agent clock
+	if data.CreateTime <= 0 || data.CreateTime > now {
+		data.CreateTime = now
+	}
+	data.ReceivedTime = now
+	s.flagCriticalTemperatures(agentID, data)
+}
+
+// flagCriticalTemperatures marks every sensor which is at or above its critical temperature
+func (s *healthService) flagCriticalTemperatures(agentID string, data *types.Health) {
+	for i, temperature := range data.Temperatures {
+		data.Temperatures[i].IsCritical = temperature.Critical > 0 && temperature.Current >= temperature.Critical
+		if data.Temperatures[i].IsCritical {
+			s.log.Warningf("sensor %s on agent: %s is at %.1f which is at or above its critical temperature of %.1f",
+				temperature.SensorKey, agentID, temperature.Current, temperature.Critical)
+		}
+	}
+}
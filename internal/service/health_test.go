@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/PR-Developers/server-health-monitor/internal/repository"
 	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
@@ -21,6 +22,7 @@ type testHealthServiceHelper struct {
 	hostRepo      repository.IHostRepository
 	healthMock    *mock.Mock
 	hostMock      *mock.Mock
+	rebootMock    *mock.Mock
 }
 
 var (
@@ -42,7 +44,8 @@ func getInitializedHealthService() testHealthServiceHelper {
 	healthRepo := new(mocks.IHealthRepository)
 	hostRepo := new(mocks.IHostRepository)
 
-	healthService := NewHealthService(healthRepo, hostRepo)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
 
 	return testHealthServiceHelper{
 		healthService: healthService,
@@ -50,6 +53,7 @@ func getInitializedHealthService() testHealthServiceHelper {
 		healthRepo:    healthRepo,
 		healthMock:    &healthRepo.Mock,
 		hostMock:      &hostRepo.Mock,
+		rebootMock:    &rebootService.Mock,
 	}
 }
 
@@ -97,6 +101,27 @@ func TestHealth_GetHealthByAgentId_ReturnsExpectedHealthData(t *testing.T) {
 	helper.healthMock.AssertExpectations(t)
 }
 
+func TestHealth_GetHealthByAgentId_ReturnsDiskUsage(t *testing.T) {
+	helper := getInitializedHealthService()
+	helper.healthMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Health{
+		{
+			AgentID: "1",
+			Disks: []types.Disk{
+				{Mountpoint: "/", Fstype: "ext4", UsedPercent: 40},
+				{Mountpoint: "/var", Fstype: "xfs", UsedPercent: 99},
+			},
+		},
+	}, nil)
+
+	res := helper.healthService.GetHealthByAgentID("1", "1")
+
+	assert.Equal(t, 2, len(res.Data[0].Disks))
+	assert.Equal(t, "/var", res.Data[0].Disks[1].Mountpoint)
+	assert.Equal(t, 99.0, res.Data[0].Disks[1].UsedPercent)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
 func TestHealth_GetHealthByAgentId_HandlesError(t *testing.T) {
 	helper := getInitializedHealthService()
 	helper.healthMock.On("Find", bson.M{"agentID": "4"}).Return(nil, fmt.Errorf("failed to get data from DB"))
@@ -125,6 +150,150 @@ func TestHealth_AddHealth_AddsExpectedHealthData(t *testing.T) {
 	helper.healthMock.AssertExpectations(t)
 }
 
+func TestHealth_AddHealth_KeepsSampleTime(t *testing.T) {
+	helper := getInitializedHealthService()
+	sampleTime := time.Now().UTC().Add(-time.Hour).UnixNano()
+	health := &types.Health{CreateTime: sampleTime}
+	helper.healthMock.On("Insert", health).Return("1234567", nil)
+
+	res := helper.healthService.AddHealth("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, sampleTime, res.Data[0].CreateTime)
+	assert.Greater(t, res.Data[0].ReceivedTime, sampleTime)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealth_ReplacesMissingOrFutureSampleTime(t *testing.T) {
+	helper := getInitializedHealthService()
+	future := time.Now().UTC().Add(time.Hour).UnixNano()
+	health := &types.Health{CreateTime: future}
+	helper.healthMock.On("Insert", health).Return("1234567", nil)
+
+	res := helper.healthService.AddHealth("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.Less(t, res.Data[0].CreateTime, future)
+	assert.Greater(t, res.Data[0].CreateTime, int64(0))
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealth_KeepsCPUUtilization(t *testing.T) {
+	helper := getInitializedHealthService()
+	health := &types.Health{
+		Uptime: 10,
+		CPU: types.CPU{
+			Total: types.CPUUtilization{CPU: "cpu-total", Usage: 50, User: 40, System: 10},
+			Cores: []types.CPUUtilization{{CPU: "cpu0", Usage: 50, User: 40, System: 10}},
+		},
+	}
+	helper.healthMock.On("Insert", health).Return("1234567", nil)
+
+	res := helper.healthService.AddHealth("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 50.0, res.Data[0].CPU.Total.Usage)
+	assert.Equal(t, "cpu0", res.Data[0].CPU.Cores[0].CPU)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealth_KeepsMemoryInformation(t *testing.T) {
+	helper := getInitializedHealthService()
+	health := &types.Health{
+		Uptime: 10,
+		Memory: types.Memory{Total: 8192, Used: 4096, Available: 2048, Cached: 1024, SwapTotal: 1024, SwapUsed: 512},
+	}
+	helper.healthMock.On("Insert", health).Return("1234567", nil)
+
+	res := helper.healthService.AddHealth("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, health.Memory, res.Data[0].Memory)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealth_FlagsCriticalTemperatures(t *testing.T) {
+	helper := getInitializedHealthService()
+	health := &types.Health{
+		Temperatures: []types.Temperature{
+			{SensorKey: "coretemp_core_0", Current: 60, High: 80, Critical: 100},
+			{SensorKey: "coretemp_core_1", Current: 100, High: 80, Critical: 100},
+			{SensorKey: "coretemp_core_2", Current: 105, High: 80, Critical: 100},
+			{SensorKey: "acpitz", Current: 105},
+		},
+	}
+	helper.healthMock.On("Insert", health).Return("1234567", nil)
+
+	res := helper.healthService.AddHealth("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.False(t, res.Data[0].Temperatures[0].IsCritical)
+	assert.True(t, res.Data[0].Temperatures[1].IsCritical)
+	assert.True(t, res.Data[0].Temperatures[2].IsCritical)
+	assert.False(t, res.Data[0].Temperatures[3].IsCritical)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealthBatch_InsertsAllSamples(t *testing.T) {
+	helper := getInitializedHealthService()
+	sampleTime := time.Now().UTC().Add(-time.Minute).UnixNano()
+	health := []types.Health{
+		{CreateTime: sampleTime, Uptime: 10},
+		{Uptime: 20, Temperatures: []types.Temperature{{SensorKey: "coretemp_core_0", Current: 100, Critical: 100}}},
+	}
+	helper.healthMock.On("InsertMany", health).Return(nil)
+
+	res := helper.healthService.AddHealthBatch("1", "1", health)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 2, len(res.Data))
+	assert.Equal(t, "1", res.Data[1].AgentID)
+	assert.NotEmpty(t, res.Data[1].ID)
+	assert.Equal(t, sampleTime, res.Data[0].CreateTime)
+	assert.Greater(t, res.Data[1].CreateTime, sampleTime)
+	assert.True(t, res.Data[1].Temperatures[0].IsCritical)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestHealth_AddHealthBatch_DetectsReboots(t *testing.T) {
+	helper := getInitializedHealthService()
+	health := []types.Health{{Uptime: 10}}
+	helper.healthMock.On("InsertMany", health).Return(nil)
+
+	helper.healthService.AddHealthBatch("1", "1", health)
+
+	helper.rebootMock.AssertCalled(t, "DetectFromHealth", "1", "1", health)
+}
+
+func TestHealth_AddHealthBatch_RejectsEmptyBatch(t *testing.T) {
+	helper := getInitializedHealthService()
+
+	res := helper.healthService.AddHealthBatch("1", "1", []types.Health{})
+
+	assert.False(t, res.Success)
+	assert.Equal(t, 400, res.StatusCode)
+	helper.healthMock.AssertNotCalled(t, "InsertMany", mock.Anything)
+}
+
+func TestHealth_AddHealthBatch_HandlesError(t *testing.T) {
+	helper := getInitializedHealthService()
+	helper.healthMock.On("InsertMany", mock.Anything).Return(fmt.Errorf("failed to insert data into DB"))
+
+	res := helper.healthService.AddHealthBatch("1", "2", []types.Health{{Uptime: 10}})
+
+	assert.Equal(t, []types.Health{}, res.Data)
+	assert.Equal(t, "failed to insert data for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	helper.healthMock.AssertExpectations(t)
+}
+
 func TestHealth_AddHealth_HandlesError(t *testing.T) {
 	helper := getInitializedHealthService()
 	helper.healthMock.On("Insert", &healthData[1]).Return("", fmt.Errorf("failed to insert data into DB"))
@@ -165,6 +334,25 @@ func TestHealth_GetLatestHealthDataByAgentID_SortsDataDescending(t *testing.T) {
 	helper.healthMock.AssertExpectations(t)
 }
 
+func TestHealth_GetLatestHealthDataByAgentID_FiltersByReceivedTime(t *testing.T) {
+	helper := getInitializedHealthService()
+	helper.healthMock.On("FindWithFilter", bson.M{
+		"agentID": bson.M{"$eq": "1"},
+		"$and": []bson.M{
+			{
+				"receivedTime": bson.M{"$gt": int64(2)},
+			},
+		},
+	}, mock.Anything).Return([]types.Health{{AgentID: "1", CreateTime: 1, ReceivedTime: 3}}, nil)
+
+	res := helper.healthService.GetLatestHealthDataByAgentID("1", "1", 2)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+
+	helper.healthMock.AssertExpectations(t)
+}
+
 func TestHealth_GetLatestHealthDataByAgentID_HandlesError(t *testing.T) {
 	helper := getInitializedHealthService()
 	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
@@ -197,6 +385,24 @@ func TestHealth_GetLatestHealthDataForAgents_ReturnsExpectedData(t *testing.T) {
 	helper.healthMock.AssertExpectations(t)
 }
 
+func TestHealth_GetLatestHealthDataForAgents_IncludesReboots(t *testing.T) {
+	rebootService := new(mocks.IRebootService)
+	healthRepo := new(mocks.IHealthRepository)
+	hostRepo := new(mocks.IHostRepository)
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	reboots := []types.Reboot{{AgentID: "1", BootTime: 1000}, {AgentID: "2", BootTime: 2000}, {AgentID: "1", BootTime: 900}}
+	hostRepo.On("Find", bson.M{}).Return([]types.Host{{AgentID: "1"}, {AgentID: "2"}, {AgentID: "3"}}, nil)
+	healthRepo.On("FindWithFilter", mock.Anything, mock.Anything).Return(healthData, nil)
+	rebootService.On("GetReboots", "1", int64(5), int64(0)).Return(types.RebootResponse{Data: reboots, Success: true}).Once()
+
+	res := healthService.GetLatestHealthDataForAgents("1", 5)
+
+	assert.Equal(t, []types.Reboot{reboots[0], reboots[2]}, res.Data[0].Reboots)
+	assert.Equal(t, []types.Reboot{reboots[1]}, res.Data[1].Reboots)
+	assert.Equal(t, []types.Reboot{}, res.Data[2].Reboots)
+	rebootService.AssertExpectations(t)
+}
+
 func TestHealth_GetLatestHealthDataForAgents_HandlesError(t *testing.T) {
 	helper := getInitializedHealthService()
 	helper.hostMock.On("Find", bson.M{}).Return(nil, fmt.Errorf("failed to connect to database"))
@@ -16,9 +16,11 @@ import (
 )
 
 type hostService struct {
-	hostRepository repository.IHostRepository
-	healthService  IHealthService
-	log            logger.Logger
+	hostRepository        repository.IHostRepository
+	hostHistoryRepository repository.IHostHistoryRepository
+	healthService         IHealthService
+	rebootService         IRebootService
+	log                   logger.Logger
 }
 
 var (
@@ -26,11 +28,13 @@ var (
 )
 
 // NewHostService returns an instanced host service
-func NewHostService(hostRepository repository.IHostRepository, healthService IHealthService) IHostService {
+func NewHostService(hostRepository repository.IHostRepository, hostHistoryRepository repository.IHostHistoryRepository, healthService IHealthService, rebootService IRebootService) IHostService {
 	return &hostService{
-		hostRepository: hostRepository,
-		healthService:  healthService,
-		log:            logger.Instance(),
+		hostRepository:        hostRepository,
+		hostHistoryRepository: hostHistoryRepository,
+		healthService:         healthService,
+		rebootService:         rebootService,
+		log:                   logger.Instance(),
 	}
 }
 
@@ -95,7 +99,8 @@ func (s *hostService) GetHostByID(requestID, agentID string, includeHealthData b
 	}
 }
 
-// AddHost inserts new host
+// AddHost inserts new host or updates the existing host of an agent, recording the inventory changes
+// of an existing host in its history once it is updated
 func (s *hostService) AddHost(requestID string, agentID string, data *types.Host) types.HostReponse {
 	s.log.Infof("attemping to insert host data for agent: %s - Request ID: %s", agentID, requestID)
 
@@ -119,6 +124,22 @@ func (s *hostService) AddHost(requestID string, agentID string, data *types.Host
 		}
 
 		s.log.Infof("successfully updated host data for agent: %s - Request ID: %s", agentID, requestID)
+		// The changes are only recorded once the host is updated, so a failed update which the agent
+		// sends again is not recorded twice
+		if changes := utils.DiffHosts(&first, data); len(changes) > 0 {
+			_, err := s.hostHistoryRepository.Insert(&types.HostHistory{
+				ID:         primitive.NewObjectID(),
+				AgentID:    agentID,
+				CreateTime: now,
+				Changes:    changes,
+			})
+			if err != nil {
+				s.log.Errorf("failed to insert host history for agent: %s - Request ID %s", agentID, requestID)
+			} else {
+				s.log.Infof("recorded %d host inventory changes for agent: %s - Request ID: %s", len(changes), agentID, requestID)
+			}
+		}
+		s.rebootService.DetectFromHost(requestID, agentID, &first, data)
 	} else {
 		data.ID = primitive.NewObjectID()
 		data.CreateTime = now
@@ -144,6 +165,37 @@ func (s *hostService) AddHost(requestID string, agentID string, data *types.Host
 	}
 }
 
+// GetHostHistoryByAgentID returns the latest inventory changes (newest first) for a given agent,
+// optionally between from and to (inclusive)
+func (s *hostService) GetHostHistoryByAgentID(requestID string, agentID string, from int64, to int64) types.HostHistoryResponse {
+	s.log.Infof("attemping to get host history for agent: %s - Request ID: %s", agentID, requestID)
+
+	query := bson.M{"agentID": agentID}
+	timeRangeFilter(query, from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	options.SetLimit(100)
+
+	data, err := s.hostHistoryRepository.FindWithFilter(query, options)
+	if err != nil {
+		return types.HostHistoryResponse{
+			Data:       []types.HostHistory{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get host history for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got host history for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.HostHistoryResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
 func (s *hostService) isHostOnline(requestID string, agentID string) bool {
 	res := s.healthService.GetLatestHealthDataByAgentID(requestID, agentID,
 		utils.GetMinimumLastHealthPacketTime(time.Now(),
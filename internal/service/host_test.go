@@ -17,12 +17,15 @@ import (
 
 //go:generate mockery --dir=../ -r --name IHealthService
 //go:generate mockery --dir=../ -r --name IHostRepository
+//go:generate mockery --dir=../ -r --name IHostHistoryRepository
 
 type testHostServiceHelper struct {
 	hostService   IHostService
 	healthService IHealthService
 	hostRepo      repository.IHostRepository
 	hostMock      *mock.Mock
+	historyMock   *mock.Mock
+	rebootMock    *mock.Mock
 	healthMock    *mock.Mock
 }
 
@@ -48,8 +51,10 @@ var (
 func getInitializedHostService() testHostServiceHelper {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	healthMock := &healthRepo.Mock
 	healthMock.On("Find", mock.Anything).Return([]types.Health{
 		{
@@ -62,6 +67,8 @@ func getInitializedHostService() testHostServiceHelper {
 		hostService:   hostService,
 		hostRepo:      hostRepo,
 		hostMock:      &hostRepo.Mock,
+		historyMock:   &historyRepo.Mock,
+		rebootMock:    &rebootService.Mock,
 		healthMock:    healthMock,
 	}
 }
@@ -101,8 +108,10 @@ func TestHost_GetHosts_HandlesError(t *testing.T) {
 func TestHost_GetHosts_IncludesHealthData(t *testing.T) {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	hostMock := &hostRepo.Mock
 	healthMock := &healthRepo.Mock
 
@@ -147,8 +156,10 @@ func TestHost_GetHostByID_ReturnsExpectedHostData(t *testing.T) {
 func TestHost_GetHostByID_IncludesHealthData(t *testing.T) {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	hostMock := &hostRepo.Mock
 	healthMock := &healthRepo.Mock
 
@@ -262,8 +273,10 @@ func TestHost_AddHost_HandlesFailedToInsertHostError(t *testing.T) {
 func TestHost_IsHostOnline_HostIsOnline(t *testing.T) {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	healthMock := &healthRepo.Mock
 
 	healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{
@@ -282,8 +295,10 @@ func TestHost_IsHostOnline_HostIsOnline(t *testing.T) {
 func TestHost_IsHostOnline_HostIsOffline(t *testing.T) {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	healthMock := &healthRepo.Mock
 	healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{}, nil)
 
@@ -313,8 +328,10 @@ func TestHost_GetHealthDataForHosts_GetsDataForActiveHost(t *testing.T) {
 func TestHost_GetHealthDataForHosts_GetsDataForInactiveHost(t *testing.T) {
 	hostRepo := new(mocks.IHostRepository)
 	healthRepo := new(mocks.IHealthRepository)
-	healthService := NewHealthService(healthRepo, hostRepo)
-	hostService := NewHostService(hostRepo, healthService)
+	rebootService := getRebootServiceMock()
+	healthService := NewHealthService(healthRepo, hostRepo, rebootService)
+	historyRepo := new(mocks.IHostHistoryRepository)
+	hostService := NewHostService(hostRepo, historyRepo, healthService, rebootService)
 	healthMock := &healthRepo.Mock
 
 	options := options.Find()
@@ -335,3 +352,84 @@ func TestHost_GetHealthDataForHosts_GetsDataForInactiveHost(t *testing.T) {
 	assert.Equal(t, 0, len(hosts[0].Health))
 	assert.Equal(t, int64(123), hosts[0].LastConnected)
 }
+
+func TestHost_AddHost_RecordsInventoryChanges(t *testing.T) {
+	helper := getInitializedHostService()
+	previous := types.Host{AgentID: "1", CreateTime: 1, Hostname: "web", KernelVersion: "5.10.0-1"}
+	helper.hostMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Host{previous}, nil)
+	helper.historyMock.On("Insert", mock.MatchedBy(func(history *types.HostHistory) bool {
+		return history.AgentID == "1" && assert.Equal(t, []types.HostChange{
+			{Field: "kernelVersion", Previous: "5.10.0-1", Current: "5.10.0-2"},
+		}, history.Changes)
+	})).Return("123", nil)
+	helper.hostMock.On("UpdateByID", mock.Anything).Return(nil)
+
+	res := helper.hostService.AddHost("1", "1", &types.Host{Hostname: "web", KernelVersion: "5.10.0-2"})
+
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, int64(1), res.Data[0].CreateTime)
+	helper.hostMock.AssertExpectations(t)
+	helper.historyMock.AssertExpectations(t)
+}
+
+func TestHost_AddHost_HandlesFailedToInsertHistoryError(t *testing.T) {
+	helper := getInitializedHostService()
+	helper.hostMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Host{{AgentID: "1", KernelVersion: "5.10.0-1"}}, nil)
+	helper.hostMock.On("UpdateByID", mock.Anything).Return(nil)
+	helper.historyMock.On("Insert", mock.Anything).Return("", fmt.Errorf("failed to insert data"))
+
+	res := helper.hostService.AddHost("1", "1", &types.Host{KernelVersion: "5.10.0-2"})
+
+	assert.Equal(t, 200, res.StatusCode)
+	assert.True(t, res.Success)
+	helper.hostMock.AssertExpectations(t)
+	helper.historyMock.AssertExpectations(t)
+}
+
+func TestHost_AddHost_DoesNotRecordHistoryWhenUpdateFails(t *testing.T) {
+	helper := getInitializedHostService()
+	helper.hostMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Host{{AgentID: "1", KernelVersion: "5.10.0-1"}}, nil)
+	helper.hostMock.On("UpdateByID", mock.Anything).Return(fmt.Errorf("failed to update data"))
+
+	res := helper.hostService.AddHost("1", "1", &types.Host{KernelVersion: "5.10.0-2"})
+
+	assert.Equal(t, 500, res.StatusCode)
+	assert.False(t, res.Success)
+	helper.historyMock.AssertNotCalled(t, "Insert", mock.Anything)
+}
+
+func TestHost_GetHostHistoryByAgentID_ReturnsChangesInTimeRange(t *testing.T) {
+	helper := getInitializedHostService()
+	history := []types.HostHistory{{AgentID: "1", CreateTime: 5, Changes: []types.HostChange{{Field: "kernelVersion"}}}}
+	helper.historyMock.On("FindWithFilter", bson.M{"agentID": "1", "createTime": bson.M{"$gte": int64(1), "$lte": int64(10)}}, mock.Anything).Return(history, nil)
+
+	res := helper.hostService.GetHostHistoryByAgentID("1", "1", 1, 10)
+
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, history, res.Data)
+	helper.historyMock.AssertExpectations(t)
+}
+
+func TestHost_GetHostHistoryByAgentID_HandlesError(t *testing.T) {
+	helper := getInitializedHostService()
+	helper.historyMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.Anything).Return(nil, fmt.Errorf("failed to read data"))
+
+	res := helper.hostService.GetHostHistoryByAgentID("1", "1", 0, 0)
+
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, []types.HostHistory{}, res.Data)
+	assert.Equal(t, "failed to get host history for agent: 1 - Request ID: 1", res.Error)
+}
+
+func TestHost_AddHost_DetectsReboots(t *testing.T) {
+	helper := getInitializedHostService()
+	previous := types.Host{AgentID: "1", CreateTime: 1, BootTime: 1000}
+	helper.hostMock.On("Find", bson.M{"agentID": "1"}).Return([]types.Host{previous}, nil)
+	helper.historyMock.On("Insert", mock.Anything).Return("123", nil)
+	helper.hostMock.On("UpdateByID", mock.Anything).Return(nil)
+	current := &types.Host{BootTime: 5000}
+
+	helper.hostService.AddHost("1", "1", current)
+
+	helper.rebootMock.AssertCalled(t, "DetectFromHost", "1", "1", &previous, current)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type probeService struct {
+	probeRepository repository.IProbeRepository
+	log             logger.Logger
+}
+
+var (
+	_ IProbeService = (*probeService)(nil)
+)
+
+// NewProbeService returns an instanced probe result service
+func NewProbeService(probeRepository repository.IProbeRepository) IProbeService {
+	return &probeService{
+		probeRepository: probeRepository,
+		log:             logger.Instance(),
+	}
+}
+
+// GetProbesByAgentID returns the probe results (newest first) for a given agent between from and to (inclusive).
+// If neither from or to are set only the latest result of each probe is returned
+func (s *probeService) GetProbesByAgentID(requestID string, agentID string, from int64, to int64) types.ProbeResponse {
+	s.log.Infof("attemping to get probe results for agent: %s - Request ID: %s", agentID, requestID)
+
+	query := bson.M{"agentID": agentID}
+	ranged := timeRangeFilter(query, from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	options.SetLimit(500)
+
+	data, err := s.probeRepository.FindWithFilter(query, options)
+	if err != nil {
+		return types.ProbeResponse{
+			Data:       []types.ProbeResult{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get probe results for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	if !ranged {
+		data = getLatestProbeResults(data)
+	}
+
+	s.log.Infof("successfully got probe results for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.ProbeResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AddProbe inserts a new probe result for a given agent
+func (s *probeService) AddProbe(requestID string, agentID string, data *types.ProbeResult) types.ProbeResponse {
+	s.log.Infof("attemping to insert probe result for agent: %s - Request ID: %s", agentID, requestID)
+
+	data.AgentID = agentID
+	data.ID = primitive.NewObjectID()
+	data.CreateTime = time.Now().UTC().UnixNano()
+
+	_, err := s.probeRepository.Insert(data)
+
+	if err != nil {
+		return types.ProbeResponse{
+			Data:       []types.ProbeResult{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert probe result for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	if !data.Success {
+		s.log.Warningf("probe %s (%s) on agent: %s failed: %s", data.Name, data.Target, agentID, data.Error)
+	}
+
+	s.log.Infof("successfully inserted probe result for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.ProbeResponse{
+		Data:       []types.ProbeResult{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// getLatestProbeResults keeps the first result of each probe from results sorted newest first
+func getLatestProbeResults(results []types.ProbeResult) []types.ProbeResult {
+	latest := []types.ProbeResult{}
+	seen := map[string]bool{}
+	for _, result := range results {
+		if seen[result.Name] {
+			continue
+		}
+		seen[result.Name] = true
+		latest = append(latest, result)
+	}
+	return latest
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate mockery --dir=../ -r --name IProbeRepository
+
+var (
+	probeData []types.ProbeResult = []types.ProbeResult{
+		{AgentID: "1", Name: "nginx", CreateTime: 30, Success: false, Error: "connection refused"},
+		{AgentID: "1", Name: "mongo", CreateTime: 20, Success: true},
+		{AgentID: "1", Name: "nginx", CreateTime: 10, Success: true},
+	}
+)
+
+func getInitializedProbeService() (IProbeService, *mock.Mock) {
+	probeRepo := new(mocks.IProbeRepository)
+	return NewProbeService(probeRepo), &probeRepo.Mock
+}
+
+func TestProbe_GetProbesByAgentID_ReturnsLatestResultOfEachProbe(t *testing.T) {
+	probeService, probeMock := getInitializedProbeService()
+	probeMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.Anything).Return(probeData, nil)
+
+	res := probeService.GetProbesByAgentID("1", "1", 0, 0)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 2, len(res.Data))
+	assert.Equal(t, "nginx", res.Data[0].Name)
+	assert.False(t, res.Data[0].Success)
+	assert.Equal(t, "mongo", res.Data[1].Name)
+
+	probeMock.AssertExpectations(t)
+}
+
+func TestProbe_GetProbesByAgentID_ReturnsAllResultsInTimeRange(t *testing.T) {
+	probeService, probeMock := getInitializedProbeService()
+	probeMock.On("FindWithFilter", bson.M{
+		"agentID":    "1",
+		"createTime": bson.M{"$gte": int64(1), "$lte": int64(30)},
+	}, mock.Anything).Return(probeData, nil)
+
+	res := probeService.GetProbesByAgentID("1", "1", 1, 30)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 3, len(res.Data))
+
+	probeMock.AssertExpectations(t)
+}
+
+func TestProbe_GetProbesByAgentID_HandlesError(t *testing.T) {
+	probeService, probeMock := getInitializedProbeService()
+	probeMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := probeService.GetProbesByAgentID("1", "1", 0, 0)
+
+	assert.Equal(t, []types.ProbeResult{}, res.Data)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get probe results for agent: 1 - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+
+	probeMock.AssertExpectations(t)
+}
+
+func TestProbe_AddProbe_AddsExpectedData(t *testing.T) {
+	probeService, probeMock := getInitializedProbeService()
+	result := &types.ProbeResult{Name: "nginx", Success: true, Latency: 12}
+	probeMock.On("Insert", result).Return("1234567", nil)
+
+	res := probeService.AddProbe("1", "1", result)
+
+	assert.True(t, res.Success)
+	assert.NotEmpty(t, res.Data[0].ID)
+	assert.Equal(t, "1", res.Data[0].AgentID)
+
+	probeMock.AssertExpectations(t)
+}
+
+func TestProbe_AddProbe_HandlesError(t *testing.T) {
+	probeService, probeMock := getInitializedProbeService()
+	result := &types.ProbeResult{}
+	probeMock.On("Insert", result).Return("", fmt.Errorf("failed to insert data into DB"))
+
+	res := probeService.AddProbe("1", "2", result)
+
+	assert.Equal(t, []types.ProbeResult{}, res.Data)
+	assert.Equal(t, "failed to insert probe result for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	probeMock.AssertExpectations(t)
+}
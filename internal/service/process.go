@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type processService struct {
+	processRepository repository.IProcessRepository
+	log               logger.Logger
+}
+
+var (
+	_ IProcessService = (*processService)(nil)
+)
+
+// NewProcessService returns an instanced process snapshot service
+func NewProcessService(processRepository repository.IProcessRepository) IProcessService {
+	return &processService{
+		processRepository: processRepository,
+		log:               logger.Instance(),
+	}
+}
+
+// GetProcessesByAgentID returns the process snapshots for a given agent between from and to (inclusive).
+// If neither from or to are set only the latest snapshot is returned
+func (s *processService) GetProcessesByAgentID(requestID string, agentID string, from int64, to int64) types.ProcessResponse {
+	s.log.Infof("attemping to get process snapshots for agent: %s - Request ID: %s", agentID, requestID)
+
+	query := bson.M{"agentID": agentID}
+	ranged := timeRangeFilter(query, from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	if ranged {
+		options.SetLimit(100)
+	} else {
+		options.SetLimit(1)
+	}
+
+	data, err := s.processRepository.FindWithFilter(query, options)
+	if err != nil {
+		return types.ProcessResponse{
+			Data:       []types.ProcessSnapshot{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get process snapshots for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got process snapshots for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.ProcessResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// AddProcesses inserts a new process snapshot for a given agent
+func (s *processService) AddProcesses(requestID string, agentID string, data *types.ProcessSnapshot) types.ProcessResponse {
+	s.log.Infof("attemping to insert process snapshot for agent: %s - Request ID: %s", agentID, requestID)
+
+	data.AgentID = agentID
+	data.ID = primitive.NewObjectID()
+	data.CreateTime = time.Now().UTC().UnixNano()
+
+	_, err := s.processRepository.Insert(data)
+
+	if err != nil {
+		return types.ProcessResponse{
+			Data:       []types.ProcessSnapshot{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to insert process snapshot for agent: %s - Request ID %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully inserted process snapshot for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.ProcessResponse{
+		Data:       []types.ProcessSnapshot{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
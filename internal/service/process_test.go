@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//go:generate mockery --dir=../ -r --name IProcessRepository
+
+func getInitializedProcessService() (IProcessService, *mock.Mock) {
+	processRepo := new(mocks.IProcessRepository)
+	return NewProcessService(processRepo), &processRepo.Mock
+}
+
+func TestProcess_GetProcessesByAgentID_ReturnsLatestSnapshot(t *testing.T) {
+	processService, processMock := getInitializedProcessService()
+	processMock.On("FindWithFilter", bson.M{"agentID": "1"}, mock.MatchedBy(func(o *options.FindOptions) bool {
+		return *o.Limit == 1
+	})).Return([]types.ProcessSnapshot{
+		{AgentID: "1", CreateTime: 10, TopCPU: []types.Process{{PID: 1, Name: "nginx"}}},
+	}, nil)
+
+	res := processService.GetProcessesByAgentID("1", "1", 0, 0)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, len(res.Data))
+	assert.Equal(t, "nginx", res.Data[0].TopCPU[0].Name)
+
+	processMock.AssertExpectations(t)
+}
+
+func TestProcess_GetProcessesByAgentID_FiltersByTimeRange(t *testing.T) {
+	processService, processMock := getInitializedProcessService()
+	processMock.On("FindWithFilter", bson.M{
+		"agentID":    "1",
+		"createTime": bson.M{"$gte": int64(5), "$lte": int64(20)},
+	}, mock.Anything).Return([]types.ProcessSnapshot{
+		{AgentID: "1", CreateTime: 10},
+		{AgentID: "1", CreateTime: 20},
+	}, nil)
+
+	res := processService.GetProcessesByAgentID("1", "1", 5, 20)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 2, len(res.Data))
+
+	processMock.AssertExpectations(t)
+}
+
+func TestProcess_GetProcessesByAgentID_HandlesError(t *testing.T) {
+	processService, processMock := getInitializedProcessService()
+	processMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := processService.GetProcessesByAgentID("1", "1", 0, 0)
+
+	assert.Equal(t, []types.ProcessSnapshot{}, res.Data)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get process snapshots for agent: 1 - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+
+	processMock.AssertExpectations(t)
+}
+
+func TestProcess_AddProcesses_AddsExpectedSnapshot(t *testing.T) {
+	processService, processMock := getInitializedProcessService()
+	snapshot := &types.ProcessSnapshot{TopMemory: []types.Process{{PID: 1, Name: "mongod", RSS: 5000}}}
+	processMock.On("Insert", snapshot).Return("1234567", nil)
+
+	res := processService.AddProcesses("1", "1", snapshot)
+
+	assert.True(t, res.Success)
+	assert.NotEmpty(t, res.Data[0].ID)
+	assert.NotZero(t, res.Data[0].CreateTime)
+	assert.Equal(t, "1", res.Data[0].AgentID)
+
+	processMock.AssertExpectations(t)
+}
+
+func TestProcess_AddProcesses_HandlesError(t *testing.T) {
+	processService, processMock := getInitializedProcessService()
+	snapshot := &types.ProcessSnapshot{}
+	processMock.On("Insert", snapshot).Return("", fmt.Errorf("failed to insert data into DB"))
+
+	res := processService.AddProcesses("1", "2", snapshot)
+
+	assert.Equal(t, []types.ProcessSnapshot{}, res.Data)
+	assert.Equal(t, "failed to insert process snapshot for agent: 2 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+
+	processMock.AssertExpectations(t)
+}
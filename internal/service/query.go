@@ -0,0 +1,26 @@
+package service
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// timeRangeFilter restricts query to documents created between from and to (inclusive), a value of 0 leaves that side open.
+// Returns true if the query was restricted
+func timeRangeFilter(query bson.M, from int64, to int64) bool {
+	return fieldRangeFilter(query, "createTime", from, to)
+}
+
+// fieldRangeFilter restricts query to documents with field between from and to (inclusive), a value of 0 leaves that side open.
+// Returns true if the query was restricted
+func fieldRangeFilter(query bson.M, field string, from int64, to int64) bool {
+	bounds := bson.M{}
+	if from > 0 {
+		bounds["$gte"] = from
+	}
+	if to > 0 {
+		bounds["$lte"] = to
+	}
+	if len(bounds) == 0 {
+		return false
+	}
+	query[field] = bounds
+	return true
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQuery_TimeRangeFilter_SetsBounds(t *testing.T) {
+	query := bson.M{"agentID": "1"}
+	assert.False(t, timeRangeFilter(query, 0, 0))
+	assert.Equal(t, bson.M{"agentID": "1"}, query)
+
+	query = bson.M{"agentID": "1"}
+	assert.True(t, timeRangeFilter(query, 5, 0))
+	assert.Equal(t, bson.M{"agentID": "1", "createTime": bson.M{"$gte": int64(5)}}, query)
+
+	query = bson.M{"agentID": "1"}
+	assert.True(t, timeRangeFilter(query, 0, 20))
+	assert.Equal(t, bson.M{"agentID": "1", "createTime": bson.M{"$lte": int64(20)}}, query)
+
+	query = bson.M{"agentID": "1"}
+	assert.True(t, timeRangeFilter(query, 5, 20))
+	assert.Equal(t, bson.M{"agentID": "1", "createTime": bson.M{"$gte": int64(5), "$lte": int64(20)}}, query)
+}
+
+func TestQuery_FieldRangeFilter_UsesField(t *testing.T) {
+	query := bson.M{"agentID": "1"}
+	assert.True(t, fieldRangeFilter(query, "eventTime", 5, 20))
+	assert.Equal(t, bson.M{"agentID": "1", "eventTime": bson.M{"$gte": int64(5), "$lte": int64(20)}}, query)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// rebootTolerance is how far (in seconds) boot times can differ and still be the same boot. The boot
+	// time of a health sample is calculated from its uptime, so it moves a little between samples. Reboots
+	// are also recorded once per window of this size
+	rebootTolerance = 60
+)
+
+type rebootService struct {
+	rebootRepository repository.IRebootRepository
+	healthRepository repository.IHealthRepository
+	log              logger.Logger
+}
+
+var (
+	_ IRebootService = (*rebootService)(nil)
+)
+
+// NewRebootService returns an instanced host reboot service
+func NewRebootService(rebootRepository repository.IRebootRepository, healthRepository repository.IHealthRepository) IRebootService {
+	return &rebootService{
+		rebootRepository: rebootRepository,
+		healthRepository: healthRepository,
+		log:              logger.Instance(),
+	}
+}
+
+// GetReboots returns the latest reboots (newest first) of every host, optionally between from and to (inclusive)
+func (s *rebootService) GetReboots(requestID string, from int64, to int64) types.RebootResponse {
+	s.log.Info("attemping to get all reboots - Request ID: " + requestID)
+
+	data, err := s.findReboots(bson.M{}, from, to)
+	if err != nil {
+		return types.RebootResponse{
+			Data:       []types.Reboot{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get all reboots - Request ID: %s", requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Info("successfully got all reboots - Request ID: " + requestID)
+
+	return types.RebootResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// GetRebootsByAgentID returns the latest reboots (newest first) for a given agent,
+// optionally between from and to (inclusive)
+func (s *rebootService) GetRebootsByAgentID(requestID string, agentID string, from int64, to int64) types.RebootResponse {
+	s.log.Infof("attemping to get reboots for agent: %s - Request ID: %s", agentID, requestID)
+
+	data, err := s.findReboots(bson.M{"agentID": agentID}, from, to)
+	if err != nil {
+		return types.RebootResponse{
+			Data:       []types.Reboot{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get reboots for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got reboots for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.RebootResponse{
+		Data:       data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// DetectFromHealth records a reboot whenever the uptime decreases between two consecutive health samples,
+// starting with the last sample stored before the given ones. The boot time calculated from a sample
+// depends on the clock of the agent, so a moved boot time is only trusted from the host information
+func (s *rebootService) DetectFromHealth(requestID string, agentID string, data []types.Health) {
+	if len(data) == 0 {
+		return
+	}
+	samples := append([]types.Health{}, data...)
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].CreateTime < samples[j].CreateTime
+	})
+
+	previous, found := s.getLastHealthBefore(agentID, samples[0].CreateTime)
+	for _, current := range samples {
+		// Samples without an uptime could not read it and say nothing about a reboot
+		if current.Uptime == 0 {
+			continue
+		}
+		if found && current.Uptime < previous.Uptime {
+			s.recordReboot(requestID, agentID, getBootTime(current), previous)
+		}
+		previous, found = current, true
+	}
+}
+
+// DetectFromHost records a reboot when the boot time of a host moved since it last reported its information
+func (s *rebootService) DetectFromHost(requestID string, agentID string, previous *types.Host, current *types.Host) {
+	if previous.BootTime == 0 || current.BootTime <= previous.BootTime+rebootTolerance {
+		return
+	}
+
+	// The host information is rarely sent, so the last health sample before the boot is usually more recent
+	last := types.Health{CreateTime: previous.UpdateTime, Uptime: previous.Uptime}
+	if sample, found := s.getLastHealthBefore(agentID, int64(current.BootTime)*int64(time.Second)); found && sample.CreateTime > last.CreateTime {
+		last = sample
+	}
+	s.recordReboot(requestID, agentID, current.BootTime, last)
+}
+
+// recordReboot inserts a reboot at bootTime following the last sample, unless it was already recorded.
+// Concurrent requests which detect the same reboot only record it once through its boot window
+func (s *rebootService) recordReboot(requestID string, agentID string, bootTime uint64, last types.Health) {
+	// A host can not have started this close to the epoch, the sample it was calculated from is broken
+	if bootTime <= rebootTolerance {
+		return
+	}
+	existing, err := s.rebootRepository.Find(bson.M{
+		"agentID":  agentID,
+		"bootTime": bson.M{"$gte": bootTime - rebootTolerance, "$lte": bootTime + rebootTolerance},
+	})
+	if err != nil || len(existing) > 0 {
+		return
+	}
+
+	reboot := &types.Reboot{
+		ID:             primitive.NewObjectID(),
+		AgentID:        agentID,
+		CreateTime:     time.Now().UTC().UnixNano(),
+		BootTime:       bootTime,
+		PreviousUptime: last.Uptime,
+		BootWindow:     bootTime - bootTime%rebootTolerance,
+	}
+	if lastTime := uint64(last.CreateTime / int64(time.Second)); bootTime > lastTime {
+		reboot.Downtime = bootTime - lastTime
+	}
+	inserted, err := s.rebootRepository.InsertIfNotExists(reboot)
+	if err != nil {
+		s.log.Errorf("failed to insert reboot for agent: %s - Request ID %s", agentID, requestID)
+		return
+	}
+	if !inserted {
+		return
+	}
+
+	s.log.Warningf("agent: %s rebooted at %s after %d seconds of uptime - Request ID: %s",
+		agentID, time.Unix(int64(bootTime), 0).UTC().Format(time.RFC3339), last.Uptime, requestID)
+}
+
+// getLastHealthBefore returns the latest health sample of an agent created before a given time
+func (s *rebootService) getLastHealthBefore(agentID string, before int64) (types.Health, bool) {
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	options.SetLimit(1)
+
+	data, err := s.healthRepository.FindWithFilter(bson.M{"agentID": agentID, "createTime": bson.M{"$lt": before}}, options)
+	if err != nil || len(data) == 0 {
+		return types.Health{}, false
+	}
+	return data[0], true
+}
+
+// findReboots returns the latest reboots matching query which were detected between from and to
+func (s *rebootService) findReboots(query bson.M, from int64, to int64) ([]types.Reboot, error) {
+	timeRangeFilter(query, from, to)
+
+	options := options.Find()
+	options.SetSort(bson.D{primitive.E{Key: "createTime", Value: -1}})
+	options.SetLimit(100)
+
+	return s.rebootRepository.FindWithFilter(query, options)
+}
+
+// getBootTime returns the unix time (in seconds) a host started at according to a health sample
+func getBootTime(health types.Health) uint64 {
+	sampleTime := uint64(health.CreateTime / int64(time.Second))
+	if health.Uptime > sampleTime {
+		return 0
+	}
+	return sampleTime - health.Uptime
+}
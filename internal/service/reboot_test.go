@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate mockery --dir=../ -r --name IRebootRepository
+//go:generate mockery --dir=../ -r --name IRebootService
+
+type testRebootServiceHelper struct {
+	rebootService IRebootService
+	rebootMock    *mock.Mock
+	healthMock    *mock.Mock
+}
+
+// bootTime is the unix time (in seconds) the hosts in these tests start at
+const bootTime = 1700000000
+
+func getInitializedRebootService() testRebootServiceHelper {
+	rebootRepo := new(mocks.IRebootRepository)
+	healthRepo := new(mocks.IHealthRepository)
+
+	return testRebootServiceHelper{
+		rebootService: NewRebootService(rebootRepo, healthRepo),
+		rebootMock:    &rebootRepo.Mock,
+		healthMock:    &healthRepo.Mock,
+	}
+}
+
+// getRebootServiceMock returns a reboot service for the tests of other services which detects nothing
+func getRebootServiceMock() *mocks.IRebootService {
+	rebootService := new(mocks.IRebootService)
+	rebootService.On("DetectFromHealth", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	rebootService.On("DetectFromHost", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	rebootService.On("GetRebootsByAgentID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(types.RebootResponse{
+		Data:    []types.Reboot{},
+		Success: true,
+	}).Maybe()
+	rebootService.On("GetReboots", mock.Anything, mock.Anything, mock.Anything).Return(types.RebootResponse{
+		Data:    []types.Reboot{},
+		Success: true,
+	}).Maybe()
+	return rebootService
+}
+
+// getSample returns a health sample taken seconds after bootTime with the given uptime
+func getSample(seconds int64, uptime uint64) types.Health {
+	return types.Health{
+		CreateTime: time.Unix(bootTime+seconds, 0).UnixNano(),
+		Uptime:     uptime,
+	}
+}
+
+func TestReboot_DetectFromHealth_RecordsDecreasingUptime(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{}, nil)
+	helper.rebootMock.On("Find", mock.Anything).Return([]types.Reboot{}, nil)
+	helper.rebootMock.On("InsertIfNotExists", mock.MatchedBy(func(reboot *types.Reboot) bool {
+		return reboot.AgentID == "1" && reboot.BootTime == bootTime+1020 &&
+			reboot.PreviousUptime == 1000 && reboot.Downtime == 20 && reboot.BootWindow == bootTime+1020-(bootTime+1020)%rebootTolerance
+	})).Return(true, nil)
+
+	helper.rebootService.DetectFromHealth("1", "1", []types.Health{getSample(1030, 10), getSample(1000, 1000), getSample(970, 970)})
+
+	helper.rebootMock.AssertNumberOfCalls(t, "InsertIfNotExists", 1)
+	helper.rebootMock.AssertExpectations(t)
+}
+
+func TestReboot_DetectFromHealth_ComparesWithStoredSample(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.healthMock.On("FindWithFilter", bson.M{"agentID": "1", "createTime": bson.M{"$lt": getSample(5000, 0).CreateTime}}, mock.Anything).
+		Return([]types.Health{getSample(4000, 4000)}, nil)
+	helper.rebootMock.On("Find", bson.M{
+		"agentID":  "1",
+		"bootTime": bson.M{"$gte": uint64(bootTime + 4900 - rebootTolerance), "$lte": uint64(bootTime + 4900 + rebootTolerance)},
+	}).Return([]types.Reboot{}, nil)
+	helper.rebootMock.On("InsertIfNotExists", mock.MatchedBy(func(reboot *types.Reboot) bool {
+		return reboot.BootTime == bootTime+4900 && reboot.PreviousUptime == 4000 && reboot.Downtime == 900
+	})).Return(true, nil)
+
+	helper.rebootService.DetectFromHealth("1", "1", []types.Health{getSample(5000, 100)})
+
+	helper.rebootMock.AssertExpectations(t)
+	helper.healthMock.AssertExpectations(t)
+}
+
+func TestReboot_DetectFromHealth_IgnoresClockSteps(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{getSample(100, 100)}, nil)
+
+	// The clock of the agent jumped forward by an hour, which moves the calculated boot time but not the uptime
+	helper.rebootService.DetectFromHealth("1", "1", []types.Health{getSample(3730, 130), getSample(3760, 160)})
+
+	helper.rebootMock.AssertNotCalled(t, "Find", mock.Anything)
+	helper.rebootMock.AssertNotCalled(t, "InsertIfNotExists", mock.Anything)
+}
+
+func TestReboot_DetectFromHealth_IgnoresSteadyUptime(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{getSample(30, 30)}, nil)
+
+	// Boot times calculated from the uptime move by a second between samples
+	helper.rebootService.DetectFromHealth("1", "1", []types.Health{getSample(61, 60), getSample(90, 0), getSample(121, 120)})
+
+	helper.rebootMock.AssertNotCalled(t, "Find", mock.Anything)
+	helper.rebootMock.AssertNotCalled(t, "InsertIfNotExists", mock.Anything)
+}
+
+func TestReboot_DetectFromHealth_RecordsRebootOnce(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{getSample(1000, 1000)}, nil)
+	helper.rebootMock.On("Find", mock.Anything).Return([]types.Reboot{{AgentID: "1", BootTime: bootTime + 1020}}, nil)
+
+	helper.rebootService.DetectFromHealth("1", "1", []types.Health{getSample(1030, 10)})
+
+	helper.rebootMock.AssertNotCalled(t, "InsertIfNotExists", mock.Anything)
+}
+
+func TestReboot_DetectFromHost_RecordsMovedBootTime(t *testing.T) {
+	helper := getInitializedRebootService()
+	previous := &types.Host{BootTime: bootTime, Uptime: 100, UpdateTime: time.Unix(bootTime+100, 0).UnixNano()}
+	helper.healthMock.On("FindWithFilter", mock.Anything, mock.Anything).Return([]types.Health{getSample(1000, 1000)}, nil)
+	helper.rebootMock.On("Find", mock.Anything).Return([]types.Reboot{}, nil)
+	helper.rebootMock.On("InsertIfNotExists", mock.MatchedBy(func(reboot *types.Reboot) bool {
+		return reboot.BootTime == bootTime+1100 && reboot.PreviousUptime == 1000 && reboot.Downtime == 100
+	})).Return(true, nil)
+
+	helper.rebootService.DetectFromHost("1", "1", previous, &types.Host{BootTime: bootTime + 1100})
+	helper.rebootService.DetectFromHost("1", "1", previous, &types.Host{BootTime: bootTime + 1})
+	helper.rebootService.DetectFromHost("1", "1", &types.Host{}, &types.Host{BootTime: bootTime + 1100})
+
+	helper.rebootMock.AssertNumberOfCalls(t, "InsertIfNotExists", 1)
+	helper.rebootMock.AssertExpectations(t)
+}
+
+func TestReboot_GetReboots_ReturnsRebootsInTimeRange(t *testing.T) {
+	helper := getInitializedRebootService()
+	reboots := []types.Reboot{{AgentID: "1", BootTime: bootTime}}
+	helper.rebootMock.On("FindWithFilter", bson.M{"createTime": bson.M{"$gte": int64(1)}}, mock.Anything).Return(reboots, nil)
+	helper.rebootMock.On("FindWithFilter", bson.M{"agentID": "1", "createTime": bson.M{"$lte": int64(10)}}, mock.Anything).Return(reboots, nil)
+
+	res := helper.rebootService.GetReboots("1", 1, 0)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, reboots, res.Data)
+
+	res = helper.rebootService.GetRebootsByAgentID("1", "1", 0, 10)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, reboots, res.Data)
+}
+
+func TestReboot_GetReboots_HandlesError(t *testing.T) {
+	helper := getInitializedRebootService()
+	helper.rebootMock.On("FindWithFilter", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to read data"))
+
+	res := helper.rebootService.GetReboots("1", 0, 0)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, []types.Reboot{}, res.Data)
+	assert.Equal(t, "failed to get all reboots - Request ID: 1", res.Error)
+
+	res = helper.rebootService.GetRebootsByAgentID("1", "1", 0, 0)
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get reboots for agent: 1 - Request ID: 1", res.Error)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/PR-Developers/server-health-monitor/internal/logger"
+	"github.com/PR-Developers/server-health-monitor/internal/repository"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/PR-Developers/server-health-monitor/internal/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type remoteConfigService struct {
+	remoteConfigRepository repository.IRemoteConfigRepository
+	log                    logger.Logger
+}
+
+var (
+	_ IRemoteConfigService = (*remoteConfigService)(nil)
+)
+
+// NewRemoteConfigService returns an instanced remote agent configuration service
+func NewRemoteConfigService(remoteConfigRepository repository.IRemoteConfigRepository) IRemoteConfigService {
+	return &remoteConfigService{
+		remoteConfigRepository: remoteConfigRepository,
+		log:                    logger.Instance(),
+	}
+}
+
+// GetEffectiveRemoteConfig returns the configuration of an agent, which is the configuration of its group
+// overridden by the configuration of the agent itself
+func (s *remoteConfigService) GetEffectiveRemoteConfig(requestID string, agentID string, group string) types.RemoteConfigResponse {
+	s.log.Infof("attemping to get effective remote config for agent: %s - Request ID: %s", agentID, requestID)
+
+	scopes := []bson.M{{"scope": consts.SCOPE_AGENT, "name": agentID}}
+	if group != "" {
+		scopes = append(scopes, bson.M{"scope": consts.SCOPE_GROUP, "name": group})
+	}
+
+	data, err := s.remoteConfigRepository.Find(bson.M{"$or": scopes})
+	if err != nil {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get effective remote config for agent: %s - Request ID: %s", agentID, requestID),
+			Success:    false,
+		}
+	}
+
+	effective := types.RemoteConfig{Scope: consts.SCOPE_AGENT, Name: agentID}
+	var agentConfig *types.RemoteConfig
+	for i := range data {
+		if data[i].Scope == consts.SCOPE_GROUP {
+			mergeRemoteConfig(&effective, &data[i])
+		} else {
+			agentConfig = &data[i]
+		}
+	}
+	if agentConfig != nil {
+		mergeRemoteConfig(&effective, agentConfig)
+	}
+
+	s.log.Infof("successfully got effective remote config for agent: %s - Request ID: %s", agentID, requestID)
+
+	return types.RemoteConfigResponse{
+		Data:       []types.RemoteConfig{effective},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// GetRemoteConfig returns the stored configuration of an agent or a group
+func (s *remoteConfigService) GetRemoteConfig(requestID string, scope string, name string) types.RemoteConfigResponse {
+	s.log.Infof("attemping to get remote config for %s: %s - Request ID: %s", scope, name, requestID)
+
+	data, err := s.remoteConfigRepository.Find(bson.M{"scope": scope, "name": name})
+	if err != nil {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to get remote config for %s: %s - Request ID: %s", scope, name, requestID),
+			Success:    false,
+		}
+	}
+	if len(data) == 0 {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusNotFound,
+			Error:      fmt.Sprintf("no remote config for %s: %s - Request ID: %s", scope, name, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully got remote config for %s: %s - Request ID: %s", scope, name, requestID)
+
+	return types.RemoteConfigResponse{
+		Data:       data[:1],
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// SetRemoteConfig replaces the configuration of an agent or a group
+func (s *remoteConfigService) SetRemoteConfig(requestID string, scope string, name string, data *types.RemoteConfig) types.RemoteConfigResponse {
+	s.log.Infof("attemping to set remote config for %s: %s - Request ID: %s", scope, name, requestID)
+
+	if err := validateRemoteConfig(scope, name, data); err != nil {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Sprintf("%s - Request ID: %s", err.Error(), requestID),
+			Success:    false,
+		}
+	}
+
+	existing, err := s.remoteConfigRepository.Find(bson.M{"scope": scope, "name": name})
+	if err != nil {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to set remote config for %s: %s - Request ID %s", scope, name, requestID),
+			Success:    false,
+		}
+	}
+
+	data.ID = primitive.NewObjectID()
+	if len(existing) > 0 {
+		data.ID = existing[0].ID
+	}
+	data.Scope = scope
+	data.Name = name
+	data.UpdateTime = time.Now().UTC().UnixNano()
+
+	if err = s.remoteConfigRepository.Upsert(data); err != nil {
+		return types.RemoteConfigResponse{
+			Data:       []types.RemoteConfig{},
+			StatusCode: http.StatusInternalServerError,
+			Error:      fmt.Sprintf("failed to set remote config for %s: %s - Request ID %s", scope, name, requestID),
+			Success:    false,
+		}
+	}
+
+	s.log.Infof("successfully set remote config for %s: %s - Request ID: %s", scope, name, requestID)
+
+	return types.RemoteConfigResponse{
+		Data:       []types.RemoteConfig{*data},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// validateRemoteConfig returns an error if the configuration can not be applied by an agent
+func validateRemoteConfig(scope string, name string, data *types.RemoteConfig) error {
+	if scope != consts.SCOPE_AGENT && scope != consts.SCOPE_GROUP {
+		return fmt.Errorf("unknown remote config scope: %s", scope)
+	}
+	if name == "" {
+		return fmt.Errorf("remote config requires a name")
+	}
+	if data.Interval < 0 {
+		return fmt.Errorf("remote config interval can not be negative")
+	}
+	for _, collector := range data.Collectors {
+		known := false
+		for _, name := range consts.COLLECTORS {
+			known = known || collector == name
+		}
+		if !known {
+			return fmt.Errorf("unknown collector: %s", collector)
+		}
+	}
+	return nil
+}
+
+// mergeRemoteConfig overrides the fields of base which are set in override. Checks and probes are merged by name
+func mergeRemoteConfig(base *types.RemoteConfig, override *types.RemoteConfig) {
+	if override.UpdateTime > base.UpdateTime {
+		base.UpdateTime = override.UpdateTime
+	}
+	if override.Interval > 0 {
+		base.Interval = override.Interval
+	}
+	if override.Collectors != nil {
+		base.Collectors = override.Collectors
+	}
+
+	base.Checks = utils.MergeChecks(base.Checks, override.Checks)
+	base.Probes = utils.MergeProbes(base.Probes, override.Probes)
+}
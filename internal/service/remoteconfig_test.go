@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/service/mocks"
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockery --dir=../ -r --name IRemoteConfigRepository
+
+func getInitializedRemoteConfigService() (IRemoteConfigService, *mock.Mock) {
+	remoteConfigRepo := new(mocks.IRemoteConfigRepository)
+	return NewRemoteConfigService(remoteConfigRepo), &remoteConfigRepo.Mock
+}
+
+func TestRemoteConfig_GetEffectiveRemoteConfig_MergesGroupAndAgent(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	remoteConfigMock.On("Find", bson.M{"$or": []bson.M{
+		{"scope": "agent", "name": "1"},
+		{"scope": "group", "name": "web"},
+	}}).Return([]types.RemoteConfig{
+		{Scope: "agent", Name: "1", UpdateTime: 20, Checks: []types.CheckDefinition{{Name: "disk", Interval: 10}}},
+		{Scope: "group", Name: "web", UpdateTime: 10, Interval: 60, Collectors: []string{"cpu"},
+			Checks: []types.CheckDefinition{{Name: "disk", Interval: 60}, {Name: "load"}}},
+	}, nil)
+
+	res := remoteConfigService.GetEffectiveRemoteConfig("1", "1", "web")
+
+	assert.True(t, res.Success)
+	assert.Equal(t, types.RemoteConfig{
+		Scope:      "agent",
+		Name:       "1",
+		UpdateTime: 20,
+		Interval:   60,
+		Collectors: []string{"cpu"},
+		Checks:     []types.CheckDefinition{{Name: "disk", Interval: 10}, {Name: "load"}},
+		Probes:     []types.ProbeDefinition{},
+	}, res.Data[0])
+
+	remoteConfigMock.AssertExpectations(t)
+}
+
+func TestRemoteConfig_GetEffectiveRemoteConfig_IgnoresGroupWhenNotSet(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	remoteConfigMock.On("Find", bson.M{"$or": []bson.M{{"scope": "agent", "name": "1"}}}).Return([]types.RemoteConfig{}, nil)
+
+	res := remoteConfigService.GetEffectiveRemoteConfig("1", "1", "")
+
+	assert.True(t, res.Success)
+	assert.Equal(t, 0, res.Data[0].Interval)
+	assert.Nil(t, res.Data[0].Collectors)
+
+	remoteConfigMock.AssertExpectations(t)
+}
+
+func TestRemoteConfig_GetEffectiveRemoteConfig_HandlesError(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	remoteConfigMock.On("Find", mock.Anything).Return(nil, fmt.Errorf("failed to get data"))
+
+	res := remoteConfigService.GetEffectiveRemoteConfig("1", "1", "web")
+
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to get effective remote config for agent: 1 - Request ID: 1", res.Error)
+	assert.False(t, res.Success)
+}
+
+func TestRemoteConfig_GetRemoteConfig_HandlesMissingConfig(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	remoteConfigMock.On("Find", bson.M{"scope": "group", "name": "web"}).Return([]types.RemoteConfig{}, nil)
+
+	res := remoteConfigService.GetRemoteConfig("1", "group", "web")
+
+	assert.Equal(t, 404, res.StatusCode)
+	assert.False(t, res.Success)
+}
+
+func TestRemoteConfig_SetRemoteConfig_KeepsExistingID(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	id := primitive.NewObjectID()
+	remoteConfigMock.On("Find", bson.M{"scope": "group", "name": "web"}).Return([]types.RemoteConfig{{ID: id}}, nil)
+	remoteConfigMock.On("Upsert", mock.Anything).Return(nil)
+
+	res := remoteConfigService.SetRemoteConfig("1", "group", "web", &types.RemoteConfig{Interval: 30, Collectors: []string{"cpu", "memory"}})
+
+	assert.True(t, res.Success)
+	assert.Equal(t, id, res.Data[0].ID)
+	assert.Equal(t, "group", res.Data[0].Scope)
+	assert.Equal(t, "web", res.Data[0].Name)
+	assert.NotZero(t, res.Data[0].UpdateTime)
+
+	remoteConfigMock.AssertExpectations(t)
+}
+
+func TestRemoteConfig_SetRemoteConfig_RejectsInvalidConfig(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+
+	res := remoteConfigService.SetRemoteConfig("1", "team", "web", &types.RemoteConfig{})
+	assert.Equal(t, 400, res.StatusCode)
+	assert.Equal(t, "unknown remote config scope: team - Request ID: 1", res.Error)
+
+	res = remoteConfigService.SetRemoteConfig("1", "group", "web", &types.RemoteConfig{Collectors: []string{"gpu"}})
+	assert.Equal(t, 400, res.StatusCode)
+	assert.Equal(t, "unknown collector: gpu - Request ID: 1", res.Error)
+
+	res = remoteConfigService.SetRemoteConfig("1", "agent", "1", &types.RemoteConfig{Interval: -1})
+	assert.Equal(t, 400, res.StatusCode)
+
+	remoteConfigMock.AssertNotCalled(t, "Upsert", mock.Anything)
+}
+
+func TestRemoteConfig_SetRemoteConfig_HandlesError(t *testing.T) {
+	remoteConfigService, remoteConfigMock := getInitializedRemoteConfigService()
+	remoteConfigMock.On("Find", mock.Anything).Return([]types.RemoteConfig{}, nil)
+	remoteConfigMock.On("Upsert", mock.Anything).Return(fmt.Errorf("failed to upsert data"))
+
+	res := remoteConfigService.SetRemoteConfig("1", "agent", "1", &types.RemoteConfig{})
+
+	assert.Equal(t, 500, res.StatusCode)
+	assert.Equal(t, "failed to set remote config for agent: 1 - Request ID 1", res.Error)
+	assert.False(t, res.Success)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/x509"
+
 	"github.com/PR-Developers/server-health-monitor/internal/types"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -10,16 +12,73 @@ type IHealthService interface {
 	GetHealth(requestID string) types.HealthReponse
 	GetHealthByAgentID(requestID, agentID string) types.HealthReponse
 	AddHealth(requestID string, agentID string, data *types.Health) types.HealthReponse
+	AddHealthBatch(requestID string, agentID string, data []types.Health) types.HealthReponse
 	GetLatestHealthDataByAgentID(requestID string, agentID string, time int64) types.HealthReponse
 	GetLatestHealthDataForAgents(requestID string, time int64) types.HostReponse
 	GetHealthForAgentWithOptions(requestID string, agentID string, options *options.FindOptions) []types.Health
 }
 
+// IRebootService is an interface which provides method signatures for a host reboot service
+type IRebootService interface {
+	GetReboots(requestID string, from int64, to int64) types.RebootResponse
+	GetRebootsByAgentID(requestID string, agentID string, from int64, to int64) types.RebootResponse
+	DetectFromHealth(requestID string, agentID string, data []types.Health)
+	DetectFromHost(requestID string, agentID string, previous *types.Host, current *types.Host)
+}
+
 // IHostService is an interface which provides method signatures for a host service
 type IHostService interface {
 	GetHosts(requestID string, includeHealthData bool) types.HostReponse
 	GetHostByID(requestID, agentID string, includeHealthData bool) types.HostReponse
 	AddHost(requestID string, agentID string, data *types.Host) types.HostReponse
+	GetHostHistoryByAgentID(requestID string, agentID string, from int64, to int64) types.HostHistoryResponse
 	isHostOnline(requestID string, agentID string) bool
 	getHealthDataForHosts(requestID string, hosts *[]types.Host)
 }
+
+// IProcessService is an interface which provides method signatures for a process snapshot service
+type IProcessService interface {
+	GetProcessesByAgentID(requestID string, agentID string, from int64, to int64) types.ProcessResponse
+	AddProcesses(requestID string, agentID string, data *types.ProcessSnapshot) types.ProcessResponse
+}
+
+// ICheckService is an interface which provides method signatures for a check result service
+type ICheckService interface {
+	GetChecksByAgentID(requestID string, agentID string, from int64, to int64) types.CheckResponse
+	AddCheck(requestID string, agentID string, data *types.CheckResult) types.CheckResponse
+}
+
+// IProbeService is an interface which provides method signatures for a probe result service
+type IProbeService interface {
+	GetProbesByAgentID(requestID string, agentID string, from int64, to int64) types.ProbeResponse
+	AddProbe(requestID string, agentID string, data *types.ProbeResult) types.ProbeResponse
+}
+
+// ICertificateService is an interface which provides method signatures for a certificate service
+type ICertificateService interface {
+	GetExpiringCertificates(requestID string, limit int64) types.CertificateResponse
+	GetCertificatesByAgentID(requestID string, agentID string) types.CertificateResponse
+	AddCertificates(requestID string, agentID string, data []types.Certificate) types.CertificateResponse
+}
+
+// IEventService is an interface which provides method signatures for a log event service
+type IEventService interface {
+	GetEventsByAgentID(requestID string, agentID string, from int64, to int64) types.EventResponse
+	AddEvent(requestID string, agentID string, data *types.Event) types.EventResponse
+}
+
+// IRemoteConfigService is an interface which provides method signatures for a remote agent configuration service
+type IRemoteConfigService interface {
+	GetEffectiveRemoteConfig(requestID string, agentID string, group string) types.RemoteConfigResponse
+	GetRemoteConfig(requestID string, scope string, name string) types.RemoteConfigResponse
+	SetRemoteConfig(requestID string, scope string, name string, data *types.RemoteConfig) types.RemoteConfigResponse
+}
+
+// IAgentService is an interface which provides method signatures for an agent enrollment service
+type IAgentService interface {
+	CreateEnrollmentToken(requestID string, data *types.EnrollmentToken) types.EnrollmentTokenResponse
+	Enroll(requestID string, agentID string, token string) types.EnrollmentResponse
+	Authenticate(requestID string, agentID string, secret string) types.AgentResponse
+	AuthenticateCertificate(requestID string, agentID string, certificate *x509.Certificate) types.AgentResponse
+	RevokeAgent(requestID string, agentID string) types.AgentResponse
+}
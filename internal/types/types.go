@@ -27,12 +27,188 @@ type HostReponse struct {
 	Success    bool
 }
 
+type ProcessResponse struct {
+	Data       []ProcessSnapshot
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type CheckResponse struct {
+	Data       []CheckResult
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type ProbeResponse struct {
+	Data       []ProbeResult
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type CertificateResponse struct {
+	Data       []Certificate
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type RemoteConfigResponse struct {
+	Data       []RemoteConfig
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type EnrollmentTokenResponse struct {
+	Data       []EnrollmentToken
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type EnrollmentResponse struct {
+	Data       []Enrollment
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type AgentResponse struct {
+	Data       []Agent
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type EventResponse struct {
+	Data       []Event
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type RebootResponse struct {
+	Data       []Reboot
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
+type HostHistoryResponse struct {
+	Data       []HostHistory
+	StatusCode int
+	Error      string
+	Success    bool
+}
+
 // Health contains all information realted to an agents health
 type Health struct {
-	ID         primitive.ObjectID `json:"_id" bson:"_id"`
-	AgentID    string             `json:"agentID" bson:"agentID"`
-	CreateTime int64              `json:"createTime" bson:"createTime"`
-	Uptime     uint64             `json:"uptime" bson:"uptime"`
+	ID           primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID      string             `json:"agentID" bson:"agentID"`
+	CreateTime   int64              `json:"createTime" bson:"createTime"`
+	ReceivedTime int64              `json:"receivedTime" bson:"receivedTime"`
+	Uptime       uint64             `json:"uptime" bson:"uptime"`
+	CPU          CPU                `json:"cpu" bson:"cpu"`
+	Memory       Memory             `json:"memory" bson:"memory"`
+	Disks        []Disk             `json:"disks" bson:"disks"`
+	DiskIO       []DiskIO           `json:"diskIO" bson:"diskIO"`
+	Network      []NetworkIO        `json:"network" bson:"network"`
+	Load         Load               `json:"load" bson:"load"`
+	Temperatures []Temperature      `json:"temperatures" bson:"temperatures"`
+	Fans         []Fan              `json:"fans" bson:"fans"`
+}
+
+// CPU contains the cpu utilization of the host and each of its cores
+type CPU struct {
+	Total CPUUtilization   `json:"total" bson:"total"`
+	Cores []CPUUtilization `json:"cores" bson:"cores"`
+}
+
+// CPUUtilization contains the percentage of time a cpu spent in each state between two samples
+type CPUUtilization struct {
+	CPU    string  `json:"cpu" bson:"cpu"`
+	Usage  float64 `json:"usage" bson:"usage"`
+	User   float64 `json:"user" bson:"user"`
+	System float64 `json:"system" bson:"system"`
+	Iowait float64 `json:"iowait" bson:"iowait"`
+	Steal  float64 `json:"steal" bson:"steal"`
+}
+
+// Memory contains the memory and swap usage of the host (in bytes)
+type Memory struct {
+	Total     uint64 `json:"total" bson:"total"`
+	Used      uint64 `json:"used" bson:"used"`
+	Available uint64 `json:"available" bson:"available"`
+	Cached    uint64 `json:"cached" bson:"cached"`
+	SwapTotal uint64 `json:"swapTotal" bson:"swapTotal"`
+	SwapUsed  uint64 `json:"swapUsed" bson:"swapUsed"`
+}
+
+// Disk contains the usage of a single mounted filesystem (in bytes)
+type Disk struct {
+	Device            string  `json:"device" bson:"device"`
+	Mountpoint        string  `json:"mountpoint" bson:"mountpoint"`
+	Fstype            string  `json:"fstype" bson:"fstype"`
+	Total             uint64  `json:"total" bson:"total"`
+	Used              uint64  `json:"used" bson:"used"`
+	Free              uint64  `json:"free" bson:"free"`
+	UsedPercent       float64 `json:"usedPercent" bson:"usedPercent"`
+	InodesTotal       uint64  `json:"inodesTotal" bson:"inodesTotal"`
+	InodesUsed        uint64  `json:"inodesUsed" bson:"inodesUsed"`
+	InodesFree        uint64  `json:"inodesFree" bson:"inodesFree"`
+	InodesUsedPercent float64 `json:"inodesUsedPercent" bson:"inodesUsedPercent"`
+}
+
+// DiskIO contains the per second io rates of a single block device
+type DiskIO struct {
+	Device              string  `json:"device" bson:"device"`
+	ReadBytesPerSecond  float64 `json:"readBytesPerSecond" bson:"readBytesPerSecond"`
+	WriteBytesPerSecond float64 `json:"writeBytesPerSecond" bson:"writeBytesPerSecond"`
+	ReadsPerSecond      float64 `json:"readsPerSecond" bson:"readsPerSecond"`
+	WritesPerSecond     float64 `json:"writesPerSecond" bson:"writesPerSecond"`
+}
+
+// NetworkIO contains the per second throughput of a single network interface
+type NetworkIO struct {
+	Interface          string  `json:"interface" bson:"interface"`
+	RxBytesPerSecond   float64 `json:"rxBytesPerSecond" bson:"rxBytesPerSecond"`
+	TxBytesPerSecond   float64 `json:"txBytesPerSecond" bson:"txBytesPerSecond"`
+	RxPacketsPerSecond float64 `json:"rxPacketsPerSecond" bson:"rxPacketsPerSecond"`
+	TxPacketsPerSecond float64 `json:"txPacketsPerSecond" bson:"txPacketsPerSecond"`
+	RxErrorsPerSecond  float64 `json:"rxErrorsPerSecond" bson:"rxErrorsPerSecond"`
+	TxErrorsPerSecond  float64 `json:"txErrorsPerSecond" bson:"txErrorsPerSecond"`
+	RxDropsPerSecond   float64 `json:"rxDropsPerSecond" bson:"rxDropsPerSecond"`
+	TxDropsPerSecond   float64 `json:"txDropsPerSecond" bson:"txDropsPerSecond"`
+}
+
+// Load contains the load averages and process counts of the host
+type Load struct {
+	Load1                    float64 `json:"load1" bson:"load1"`
+	Load5                    float64 `json:"load5" bson:"load5"`
+	Load15                   float64 `json:"load15" bson:"load15"`
+	ProcsTotal               uint64  `json:"procsTotal" bson:"procsTotal"`
+	ProcsRunning             uint64  `json:"procsRunning" bson:"procsRunning"`
+	ProcsBlocked             uint64  `json:"procsBlocked" bson:"procsBlocked"`
+	ContextSwitches          uint64  `json:"contextSwitches" bson:"contextSwitches"`
+	ContextSwitchesPerSecond float64 `json:"contextSwitchesPerSecond" bson:"contextSwitchesPerSecond"`
+}
+
+// Temperature contains a single temperature sensor reading (in degrees celsius)
+type Temperature struct {
+	SensorKey  string  `json:"sensorKey" bson:"sensorKey"`
+	Current    float64 `json:"current" bson:"current"`
+	High       float64 `json:"high" bson:"high"`
+	Critical   float64 `json:"critical" bson:"critical"`
+	IsCritical bool    `json:"isCritical" bson:"isCritical"`
+}
+
+// Fan contains a single fan sensor reading
+type Fan struct {
+	SensorKey string `json:"sensorKey" bson:"sensorKey"`
+	RPM       uint64 `json:"rpm" bson:"rpm"`
 }
 
 // Host contains all information about the agent/host
@@ -57,9 +233,233 @@ type Host struct {
 	Online               bool               `json:"online" bson:",omitempty"`
 	LastConnected        int64              `json:"lastConnected" bson:",omitempty"`
 	Health               []Health           `json:"health" bson:",omitempty"`
+	Reboots              []Reboot           `json:"reboots" bson:"-"`
+}
+
+// HostHistory contains the inventory changes of a host found when it reported its information
+type HostHistory struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	Changes    []HostChange       `json:"changes" bson:"changes"`
+}
+
+// HostChange contains the previous and current value of a single inventory field of a host
+type HostChange struct {
+	Field    string `json:"field" bson:"field"` // json name of the field in Host
+	Previous string `json:"previous" bson:"previous"`
+	Current  string `json:"current" bson:"current"`
+}
+
+// Reboot contains a restart of a host which was detected from its uptime or boot time
+type Reboot struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID        string             `json:"agentID" bson:"agentID"`
+	CreateTime     int64              `json:"createTime" bson:"createTime"`         // time the reboot was detected at
+	BootTime       uint64             `json:"bootTime" bson:"bootTime"`             // unix time (in seconds) the host started at
+	PreviousUptime uint64             `json:"previousUptime" bson:"previousUptime"` // uptime (in seconds) of the last sample before the reboot
+	Downtime       uint64             `json:"downtime" bson:"downtime"`             // seconds between the last sample before the reboot and the boot
+	BootWindow     uint64             `json:"-" bson:"bootWindow"`                  // boot time rounded down, a reboot is recorded once per window
+}
+
+// AgentStatus contains the state of a data-collector which is served on its local status endpoint
+type AgentStatus struct {
+	AgentID         string         `json:"agentID"`
+	Version         string         `json:"version"`
+	StartTime       int64          `json:"startTime"`
+	LastCollectTime int64          `json:"lastCollectTime"`
+	LastSendTime    int64          `json:"lastSendTime"` // last successful request to the api, 0 if there was none
+	QueueDepth      int            `json:"queueDepth"`   // buffered packets waiting to be sent
+	Errors          map[string]int `json:"errors"`       // failed requests by endpoint
+}
+
+// ProcessSnapshot contains the top processes of an agent at a given time
+type ProcessSnapshot struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	TopCPU     []Process          `json:"topCPU" bson:"topCPU"`
+	TopMemory  []Process          `json:"topMemory" bson:"topMemory"`
+}
+
+// Process contains information about a single running process
+type Process struct {
+	PID        int32   `json:"pid" bson:"pid"`
+	Name       string  `json:"name" bson:"name"`
+	Cmdline    string  `json:"cmdline" bson:"cmdline"`
+	User       string  `json:"user" bson:"user"`
+	CPUPercent float64 `json:"cpuPercent" bson:"cpuPercent"`
+	RSS        uint64  `json:"rss" bson:"rss"`
+}
+
+// CheckResult contains the result of a single nagios compatible check run by an agent
+type CheckResult struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	CheckTime  int64              `json:"checkTime" bson:"checkTime"`
+	Name       string             `json:"name" bson:"name"`
+	Status     string             `json:"status" bson:"status"`
+	ExitCode   int                `json:"exitCode" bson:"exitCode"`
+	Output     string             `json:"output" bson:"output"`
+	Perfdata   []Perfdata         `json:"perfdata" bson:"perfdata"`
+	Duration   int64              `json:"duration" bson:"duration"` // in milliseconds
+}
+
+// Perfdata contains a single nagios performance data item. Thresholds are kept as strings as they may be ranges
+type Perfdata struct {
+	Label    string  `json:"label" bson:"label"`
+	Value    float64 `json:"value" bson:"value"`
+	UOM      string  `json:"uom" bson:"uom"`
+	Warning  string  `json:"warning" bson:"warning"`
+	Critical string  `json:"critical" bson:"critical"`
+	Min      string  `json:"min" bson:"min"`
+	Max      string  `json:"max" bson:"max"`
+}
+
+// ProbeResult contains the result of a single synthetic probe run by an agent
+type ProbeResult struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	ProbeTime  int64              `json:"probeTime" bson:"probeTime"`
+	Name       string             `json:"name" bson:"name"`
+	Type       string             `json:"type" bson:"type"`
+	Target     string             `json:"target" bson:"target"`
+	Success    bool               `json:"success" bson:"success"`
+	Latency    int64              `json:"latency" bson:"latency"` // in milliseconds
+	StatusCode int                `json:"statusCode" bson:"statusCode"`
+	Error      string             `json:"error" bson:"error"`
+}
+
+// Certificate contains information about a single certificate found by an agent. If the
+// source could not be read only the source and error are set
+type Certificate struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID     string             `json:"agentID" bson:"agentID"`
+	CreateTime  int64              `json:"createTime" bson:"createTime"`
+	Source      string             `json:"source" bson:"source"`
+	Subject     string             `json:"subject" bson:"subject"`
+	SANs        []string           `json:"sans" bson:"sans"`
+	Issuer      string             `json:"issuer" bson:"issuer"`
+	Fingerprint string             `json:"fingerprint" bson:"fingerprint"`
+	NotBefore   int64              `json:"notBefore" bson:"notBefore"`
+	NotAfter    int64              `json:"notAfter" bson:"notAfter"`
+	Error       string             `json:"error" bson:"error"`
+}
+
+// Event contains the number of lines matching a log rule since the previous event and a sample of those lines
+type Event struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	EventTime  int64              `json:"eventTime" bson:"eventTime"`
+	Source     string             `json:"source" bson:"source"`
+	Rule       string             `json:"rule" bson:"rule"`
+	Count      int                `json:"count" bson:"count"`
+	Samples    []string           `json:"samples" bson:"samples"`
+}
+
+// AgentConfig contains the configuration of an agent which can not be expressed as a single variable
+type AgentConfig struct {
+	Checks       []CheckDefinition       `json:"checks" bson:"checks"`
+	Probes       []ProbeDefinition       `json:"probes" bson:"probes"`
+	Certificates []CertificateDefinition `json:"certificates" bson:"certificates"`
+	Logs         []LogDefinition         `json:"logs" bson:"logs"`
 }
 
-// AgentInformation contains an ID which is used to differentiate between different agents
+// RemoteConfig contains configuration held by the api for a single agent or a group of agents.
+// Unset fields fall back to the group configuration and then to the local configuration of the agent
+type RemoteConfig struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	Scope      string             `json:"scope" bson:"scope"`
+	Name       string             `json:"name" bson:"name"`
+	UpdateTime int64              `json:"updateTime" bson:"updateTime"`
+	Interval   int                `json:"interval" bson:"interval"`     // in seconds
+	Collectors []string           `json:"collectors" bson:"collectors"` // nil enables the default collectors
+	Checks     []CheckDefinition  `json:"checks" bson:"checks"`
+	Probes     []ProbeDefinition  `json:"probes" bson:"probes"`
+}
+
+// CheckDefinition contains a nagios compatible check command which an agent runs on an interval
+type CheckDefinition struct {
+	Name     string   `json:"name" bson:"name"`
+	Command  []string `json:"command" bson:"command"`
+	Interval int      `json:"interval" bson:"interval"` // in seconds
+	Timeout  int      `json:"timeout" bson:"timeout"`   // in seconds
+}
+
+// ProbeDefinition contains a HTTP(S) or TCP probe which an agent runs on an interval.
+// For HTTP probes the target is a URL, for TCP probes the target is a host:port
+type ProbeDefinition struct {
+	Name               string `json:"name" bson:"name"`
+	Type               string `json:"type" bson:"type"`
+	Target             string `json:"target" bson:"target"`
+	ExpectedStatus     int    `json:"expectedStatus" bson:"expectedStatus"`
+	BodyRegex          string `json:"bodyRegex" bson:"bodyRegex"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify" bson:"insecureSkipVerify"`
+	LatencyBudget      int    `json:"latencyBudget" bson:"latencyBudget"` // in milliseconds
+	Interval           int    `json:"interval" bson:"interval"`           // in seconds
+	Timeout            int    `json:"timeout" bson:"timeout"`             // in seconds
+}
+
+// CertificateDefinition contains a PEM file or TLS endpoint (host:port) which an agent
+// inspects on an interval. Only one of file or endpoint should be set
+type CertificateDefinition struct {
+	File     string `json:"file" bson:"file"`
+	Endpoint string `json:"endpoint" bson:"endpoint"`
+	Interval int    `json:"interval" bson:"interval"` // in seconds
+}
+
+// LogDefinition contains a log file which an agent follows and the rules each new line is matched against
+type LogDefinition struct {
+	File     string    `json:"file" bson:"file"`
+	Rules    []LogRule `json:"rules" bson:"rules"`
+	Interval int       `json:"interval" bson:"interval"` // in seconds
+}
+
+// LogRule contains a named regular expression which log lines are matched against
+type LogRule struct {
+	Name    string `json:"name" bson:"name"`
+	Pattern string `json:"pattern" bson:"pattern"`
+}
+
+// AgentInformation contains an ID which is used to differentiate between different agents and
+// the secret the agent authenticates with once it is enrolled
 type AgentInformation struct {
-	ID uuid.UUID
+	ID     uuid.UUID
+	Secret string
+}
+
+// EnrollmentToken contains a token which agents can exchange for a secret until it has been used
+// MaxUses times or expires. Only a hash of the token is stored
+type EnrollmentToken struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	Token      string             `json:"token,omitempty" bson:"-"` // only returned when the token is created
+	Hash       string             `json:"-" bson:"hash"`
+	MaxUses    int                `json:"maxUses" bson:"maxUses"`
+	Uses       int                `json:"uses" bson:"uses"`
+	CreateTime int64              `json:"createTime" bson:"createTime"`
+	ExpireTime int64              `json:"expireTime" bson:"expireTime"`               // 0 never expires
+	AgentID    string             `json:"agentID,omitempty" bson:"agentID,omitempty"` // only this agent can enroll, even when it is already enrolled or revoked
+}
+
+// EnrollmentRequest contains the token an agent sends to enroll
+type EnrollmentRequest struct {
+	Token string `json:"token"`
+}
+
+// Enrollment contains the secret an enrolled agent authenticates with
+type Enrollment struct {
+	AgentID string `json:"agentID"`
+	Secret  string `json:"secret"`
+}
+
+// Agent contains an enrolled agent. Only a hash of its secret is stored
+type Agent struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	AgentID    string             `json:"agentID" bson:"agentID"`
+	SecretHash string             `json:"-" bson:"secretHash"`
+	EnrollTime int64              `json:"enrollTime" bson:"enrollTime"`
+	Revoked    bool               `json:"revoked" bson:"revoked"`
 }
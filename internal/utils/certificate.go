@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+// ParseCertificates returns every certificate in PEM encoded data. source is used to
+// describe where the certificates came from (ie, a file path or endpoint)
+func ParseCertificates(data []byte, source string) ([]types.Certificate, error) {
+	certificates := []types.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, GetCertificateInformation(certificate, source))
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificates found in %s", source)
+	}
+	return certificates, nil
+}
+
+// GetCertificateInformation returns the information used to monitor a certificate
+func GetCertificateInformation(certificate *x509.Certificate, source string) types.Certificate {
+	fingerprint := sha256.Sum256(certificate.Raw)
+	sans := append([]string{}, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, email := range certificate.EmailAddresses {
+		sans = append(sans, email)
+	}
+	for _, uri := range certificate.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	return types.Certificate{
+		Source:      source,
+		Subject:     certificate.Subject.String(),
+		SANs:        sans,
+		Issuer:      certificate.Issuer.String(),
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		NotBefore:   clampUnixNano(certificate.NotBefore),
+		NotAfter:    clampUnixNano(certificate.NotAfter),
+	}
+}
+
+// clampUnixNano returns t as unix time in nanoseconds, times which can not be represented (after 2262 or before 1678,
+// ie, a certificate which never expires) are clamped to the latest or earliest representable time
+func clampUnixNano(t time.Time) int64 {
+	if t.After(time.Unix(0, math.MaxInt64)) {
+		return math.MaxInt64
+	}
+	if t.Before(time.Unix(0, math.MinInt64)) {
+		return math.MinInt64
+	}
+	return t.UnixNano()
+}
+
+// CertificateMatchesAgent returns if a client certificate identifies an agent. The agent ID has to be
+// the common name of the subject or one of the SANs, a "urn:uuid:" URI SAN also matches the bare ID
+func CertificateMatchesAgent(certificate *x509.Certificate, agentID string) bool {
+	if agentID == "" {
+		return false
+	}
+
+	identities := []string{certificate.Subject.CommonName}
+	identities = append(identities, certificate.DNSNames...)
+	identities = append(identities, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		identities = append(identities, uri.String())
+		if uri.Scheme == "urn" && strings.HasPrefix(uri.Opaque, "uuid:") {
+			identities = append(identities, strings.TrimPrefix(uri.Opaque, "uuid:"))
+		}
+	}
+
+	for _, identity := range identities {
+		if strings.EqualFold(identity, agentID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCertificate(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notAfter.Add(-time.Hour * 24 * 30),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificate_ParseCertificates_ReturnsAllCertificates(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := append(createTestCertificate(t, "localhost", notAfter), createTestCertificate(t, "ca.local", notAfter.Add(time.Hour))...)
+
+	certificates, err := ParseCertificates(data, "/certs/localhost.crt")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(certificates))
+	assert.Equal(t, "/certs/localhost.crt", certificates[0].Source)
+	assert.Equal(t, "CN=localhost", certificates[0].Subject)
+	assert.Equal(t, "CN=localhost", certificates[0].Issuer)
+	assert.Equal(t, []string{"localhost", "127.0.0.1"}, certificates[0].SANs)
+	assert.Equal(t, notAfter.UnixNano(), certificates[0].NotAfter)
+	assert.Equal(t, 64, len(certificates[0].Fingerprint))
+	assert.Equal(t, "CN=ca.local", certificates[1].Subject)
+}
+
+func TestCertificate_ParseCertificates_ClampsExpiryAfter2262(t *testing.T) {
+	notAfter := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	certificates, err := ParseCertificates(createTestCertificate(t, "localhost", notAfter), "/certs/localhost.crt")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), certificates[0].NotAfter)
+	assert.Equal(t, int64(math.MaxInt64), certificates[0].NotBefore)
+}
+
+func TestCertificate_ParseCertificates_HandlesMissingCertificates(t *testing.T) {
+	certificates, err := ParseCertificates([]byte("not a certificate"), "test.crt")
+
+	assert.Nil(t, certificates)
+	assert.Equal(t, "no certificates found in test.crt", err.Error())
+}
+
+func TestCertificate_CertificateMatchesAgent_ChecksSubjectAndSANs(t *testing.T) {
+	agentID := "0b9a5e4c-6c2d-4c0a-9a8e-4f4f3c1d2e7a"
+	uri, _ := url.Parse("urn:uuid:" + agentID)
+	certificate := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "web-1"},
+		DNSNames: []string{"web-1.internal"},
+		URIs:     []*url.URL{uri},
+	}
+
+	assert.True(t, CertificateMatchesAgent(certificate, "web-1"))
+	assert.True(t, CertificateMatchesAgent(certificate, "web-1.internal"))
+	assert.True(t, CertificateMatchesAgent(certificate, agentID))
+	assert.True(t, CertificateMatchesAgent(certificate, "urn:uuid:"+agentID))
+	assert.False(t, CertificateMatchesAgent(certificate, "web-2"))
+	assert.False(t, CertificateMatchesAgent(&x509.Certificate{}, ""))
+}
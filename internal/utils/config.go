@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SOURCE_FLAG is the source of a value read from the command line
+	SOURCE_FLAG = "flag"
+	// SOURCE_ENV is the source of a value read from an environment variable
+	SOURCE_ENV = "env"
+	// SOURCE_FILE is the source of a value read from the config file
+	SOURCE_FILE = "file"
+	// SOURCE_DEFAULT is the source of a preset value
+	SOURCE_DEFAULT = "default"
+
+	redacted = "********"
+)
+
+var (
+	flagValues = map[string]string{}
+	fileValues = map[string]string{}
+)
+
+// LoadConfig parses the command line arguments, which contain a flag for every key (API_URL
+// is set with --api-url), and reads the config file. Returns true when --print-config was passed
+func LoadConfig(name string, args []string) (bool, error) {
+	return LoadConfigFlags(flag.NewFlagSet(name, flag.ContinueOnError), args)
+}
+
+// LoadConfigFlags works like LoadConfig but parses the arguments with a given flag set, so
+// commands can define flags of their own next to the flags of every key
+func LoadConfigFlags(flags *flag.FlagSet, args []string) (bool, error) {
+	flagPointers := map[string]*string{}
+	for _, key := range consts.KEYS {
+		flagPointers[key] = flags.String(getFlagName(key), "", fmt.Sprintf("overrides the %s environment variable", key))
+	}
+	printConfig := flags.Bool("print-config", false, "prints the effective value and source of every key and exits")
+	if err := flags.Parse(args); err != nil {
+		return false, err
+	}
+
+	flagValues = map[string]string{}
+	for key, value := range flagPointers {
+		if *value != "" {
+			flagValues[key] = *value
+		}
+	}
+
+	fileValues = map[string]string{}
+	// The config file can not point to another config file
+	file, _ := lookupVariable(consts.CONFIG_FILE, flagValues[consts.CONFIG_FILE])
+	if file == "" {
+		return *printConfig, nil
+	}
+	values, err := readConfigFile(file)
+	if err != nil {
+		return false, err
+	}
+	delete(values, consts.CONFIG_FILE)
+	fileValues = values
+	return *printConfig, nil
+}
+
+// getFlagName returns the command line flag of a key
+func getFlagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+// PrintConfig writes the effective value and source of every key, hiding secret values
+func PrintConfig(w io.Writer) {
+	for _, key := range consts.KEYS {
+		value, source := lookupVariable(key, flagValues[key])
+		if value != "" && isSecret(key) {
+			value = redacted
+		}
+		fmt.Fprintf(w, "%s=%s (%s)\n", key, value, source)
+	}
+}
+
+// readConfigFile returns the values of a JSON or YAML config file, YAML is used for .yaml and .yml files
+func readConfigFile(file string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s (%s)", file, err.Error())
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		// Numbers are kept as written, otherwise large numbers are formatted in scientific notation
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %s (%s)", file, err.Error())
+	}
+
+	values := map[string]string{}
+	for key, value := range raw {
+		if value != nil {
+			values[strings.ToUpper(key)] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// isSecret returns if the value of a key must never be shown
+func isSecret(key string) bool {
+	for _, secret := range consts.SECRET_KEYS {
+		if key == secret {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFile(t *testing.T, name string, data string) string {
+	file := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(file, []byte(data), 0644))
+	return file
+}
+
+func resetConfig() {
+	os.Clearenv()
+	flagValues = map[string]string{}
+	fileValues = map[string]string{}
+}
+
+func TestConfig_LoadConfig_LayersFlagsEnvironmentFileAndDefaults(t *testing.T) {
+	resetConfig()
+	defer resetConfig()
+	file := writeConfigFile(t, "config.json", `{"API_PORT": 1000, "DB_NAME": "file", "LOG_FILE": "file.log", "MAX_DECOMPRESSED_SIZE": 10485760}`)
+	os.Setenv(consts.DB_NAME, "env")
+	os.Setenv(consts.LOG_FILE, "env.log")
+
+	printConfig, err := LoadConfig("test", []string{"--config-file", file, "--log-file", "flag.log"})
+
+	assert.Nil(t, err)
+	assert.False(t, printConfig)
+	assert.Equal(t, "flag.log", GetVariable(consts.LOG_FILE))
+	assert.Equal(t, "env", GetVariable(consts.DB_NAME))
+	assert.Equal(t, "1000", GetVariable(consts.API_PORT))
+	assert.Equal(t, "10485760", GetVariable(consts.MAX_DECOMPRESSED_SIZE))
+	assert.Equal(t, "admin", GetVariable(consts.DB_USER))
+}
+
+func TestConfig_LoadConfig_ReadsYAMLFiles(t *testing.T) {
+	resetConfig()
+	defer resetConfig()
+	os.Setenv(consts.CONFIG_FILE, writeConfigFile(t, "config.yaml", "API_PORT: 1000\nprocess_snapshot_enabled: true\n"))
+
+	_, err := LoadConfig("test", []string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1000", GetVariable(consts.API_PORT))
+	assert.Equal(t, "true", GetVariable(consts.PROCESS_SNAPSHOT_ENABLED))
+}
+
+func TestConfig_LoadConfig_HandlesErrors(t *testing.T) {
+	resetConfig()
+	defer resetConfig()
+
+	_, err := LoadConfig("test", []string{"--unknown"})
+	assert.NotNil(t, err)
+
+	_, err = LoadConfig("test", []string{"--config-file", "missing.json"})
+	assert.Contains(t, err.Error(), "failed to read config file: missing.json")
+
+	_, err = LoadConfig("test", []string{"--config-file", writeConfigFile(t, "config.json", "{")})
+	assert.Contains(t, err.Error(), "failed to parse config file")
+}
+
+func TestConfig_LoadConfigFlags_ParsesCommandFlags(t *testing.T) {
+	resetConfig()
+	defer resetConfig()
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	once := flags.Bool("once", false, "")
+
+	_, err := LoadConfigFlags(flags, []string{"--once", "--log-file", "flag.log", "extra"})
+
+	assert.Nil(t, err)
+	assert.True(t, *once)
+	assert.Equal(t, "flag.log", GetVariable(consts.LOG_FILE))
+	assert.Equal(t, []string{"extra"}, flags.Args())
+}
+
+func TestConfig_PrintConfig_ShowsSourcesAndRedactsSecrets(t *testing.T) {
+	resetConfig()
+	defer resetConfig()
+	os.Setenv(consts.DB_PASS, "hunter2")
+
+	printConfig, err := LoadConfig("test", []string{"--print-config", "--api-port", "5000"})
+	var output bytes.Buffer
+	PrintConfig(&output)
+
+	assert.Nil(t, err)
+	assert.True(t, printConfig)
+	assert.Contains(t, output.String(), "API_PORT=5000 (flag)\n")
+	assert.Contains(t, output.String(), "DB_PASS=******** (env)\n")
+	assert.Contains(t, output.String(), "DB_USER=admin (default)\n")
+	assert.NotContains(t, output.String(), "hunter2")
+}
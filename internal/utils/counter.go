@@ -0,0 +1,16 @@
+package utils
+
+import "math"
+
+// GetCounterDelta returns the difference between two samples of an ever increasing counter.
+// If the counter went backwards and the previous value fits in 32 bits it is assumed the counter
+// wrapped around, otherwise it is assumed the counter was reset and the current value is used
+func GetCounterDelta(previous uint64, current uint64) uint64 {
+	if current >= previous {
+		return current - previous
+	}
+	if previous <= math.MaxUint32 {
+		return math.MaxUint32 - previous + current + 1
+	}
+	return current
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter_GetCounterDelta_ReturnsDifference(t *testing.T) {
+	assert.Equal(t, uint64(0), GetCounterDelta(10, 10))
+	assert.Equal(t, uint64(90), GetCounterDelta(10, 100))
+}
+
+func TestCounter_GetCounterDelta_HandlesWraparound(t *testing.T) {
+	assert.Equal(t, uint64(11), GetCounterDelta(math.MaxUint32-5, 5))
+}
+
+func TestCounter_GetCounterDelta_HandlesReset(t *testing.T) {
+	assert.Equal(t, uint64(5), GetCounterDelta(math.MaxUint32+100, 5))
+}
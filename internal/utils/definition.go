@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+// MergeChecks returns the checks of base with every check of override added, replacing checks with the same name
+func MergeChecks(base []types.CheckDefinition, override []types.CheckDefinition) []types.CheckDefinition {
+	merged := append([]types.CheckDefinition{}, base...)
+	for _, check := range override {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == check.Name {
+				merged[i] = check
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, check)
+		}
+	}
+	return merged
+}
+
+// MergeProbes returns the probes of base with every probe of override added, replacing probes with the same name
+func MergeProbes(base []types.ProbeDefinition, override []types.ProbeDefinition) []types.ProbeDefinition {
+	merged := append([]types.ProbeDefinition{}, base...)
+	for _, probe := range override {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == probe.Name {
+				merged[i] = probe
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, probe)
+		}
+	}
+	return merged
+}
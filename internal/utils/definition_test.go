@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinition_MergeChecks_ReplacesChecksWithTheSameName(t *testing.T) {
+	base := []types.CheckDefinition{{Name: "disk", Interval: 60}, {Name: "load", Interval: 60}}
+
+	merged := MergeChecks(base, []types.CheckDefinition{{Name: "load", Interval: 10}, {Name: "ntp"}})
+
+	assert.Equal(t, []types.CheckDefinition{{Name: "disk", Interval: 60}, {Name: "load", Interval: 10}, {Name: "ntp"}}, merged)
+	assert.Equal(t, 60, base[1].Interval)
+}
+
+func TestDefinition_MergeProbes_ReplacesProbesWithTheSameName(t *testing.T) {
+	base := []types.ProbeDefinition{{Name: "nginx", Target: "http://localhost"}}
+
+	merged := MergeProbes(base, []types.ProbeDefinition{{Name: "nginx", Target: "https://localhost"}, {Name: "mongo"}})
+
+	assert.Equal(t, []types.ProbeDefinition{{Name: "nginx", Target: "https://localhost"}, {Name: "mongo"}}, merged)
+}
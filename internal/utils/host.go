@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+)
+
+// bootTimeTolerance is the difference in seconds between two boot times which is not a reboot. Some
+// platforms calculate the boot time from the uptime, so it can move by a second between calls
+const bootTimeTolerance = 2
+
+// DiffHosts returns the inventory fields which differ between two reports of the same host. Values
+// which change all the time, such as the uptime and number of processes, are not part of the inventory
+func DiffHosts(previous, current *types.Host) []types.HostChange {
+	changes := []types.HostChange{}
+	compare := func(field string, previous, current interface{}) {
+		if previous != current {
+			changes = append(changes, types.HostChange{
+				Field:    field,
+				Previous: fmt.Sprint(previous),
+				Current:  fmt.Sprint(current),
+			})
+		}
+	}
+
+	compare("hostname", previous.Hostname, current.Hostname)
+	compare("os", previous.OS, current.OS)
+	compare("platform", previous.Platform, current.Platform)
+	compare("platformFamily", previous.PlatformFamily, current.PlatformFamily)
+	compare("platformVersion", previous.PlatformVersion, current.PlatformVersion)
+	compare("kernelVersion", previous.KernelVersion, current.KernelVersion)
+	compare("kernelArch", previous.KernelArch, current.KernelArch)
+	compare("virtualizationSystem", previous.VirtualizationSystem, current.VirtualizationSystem)
+	compare("virtualizationRole", previous.VirtualizationRole, current.VirtualizationRole)
+	compare("hostId", previous.HostID, current.HostID)
+	if previous.BootTime+bootTimeTolerance < current.BootTime || current.BootTime+bootTimeTolerance < previous.BootTime {
+		compare("bootTime", previous.BootTime, current.BootTime)
+	}
+	return changes
+}
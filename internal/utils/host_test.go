@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHost_DiffHosts_ReturnsInventoryChanges(t *testing.T) {
+	previous := &types.Host{Hostname: "web", KernelVersion: "5.10.0-1", BootTime: 1000, Uptime: 10, Procs: 100}
+	current := &types.Host{Hostname: "web", KernelVersion: "5.10.0-2", BootTime: 2000, Uptime: 5, Procs: 90}
+
+	changes := DiffHosts(previous, current)
+
+	assert.Equal(t, []types.HostChange{
+		{Field: "kernelVersion", Previous: "5.10.0-1", Current: "5.10.0-2"},
+		{Field: "bootTime", Previous: "1000", Current: "2000"},
+	}, changes)
+}
+
+func TestHost_DiffHosts_IgnoresVolatileFields(t *testing.T) {
+	previous := &types.Host{Hostname: "web", BootTime: 1000, Uptime: 10, Procs: 100, UpdateTime: 1}
+	current := &types.Host{Hostname: "web", BootTime: 1001, Uptime: 70, Procs: 90, UpdateTime: 2}
+
+	assert.Empty(t, DiffHosts(previous, current))
+	assert.Empty(t, DiffHosts(current, previous))
+}
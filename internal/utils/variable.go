@@ -7,24 +7,32 @@ import (
 )
 
 // GetVariable returns a value given a key.
-// GetVariable first tries to read from environment variables and will default
-// to preset values
+// GetVariable first tries to read from command line arguments (see LoadConfig),
+// environment variables, the config file and will default to preset values
 func GetVariable(key string) string {
-	return GetVariableWithArgs(key, "")
+	return GetVariableWithArgs(key, flagValues[key])
 }
 
 // GetVariableWithArgs returns a value given a key.
-// GetVariableWithArgs first tries to read from command line arguments,
-// environment variables, and will default to preset values
+// GetVariableWithArgs first tries to read from the given command line argument value,
+// environment variables, the config file and will default to preset values
 func GetVariableWithArgs(key string, args string) string {
-	// TODO: read from arguments
+	value, _ := lookupVariable(key, args)
+	return value
+}
+
+// lookupVariable returns the value of a key and where it was read from
+func lookupVariable(key string, args string) (string, string) {
 	if args != "" {
+		return args, SOURCE_FLAG
+	}
+	if value := os.Getenv(key); value != "" {
+		return value, SOURCE_ENV
 	}
-	value := os.Getenv(key)
-	if value != "" {
-		return value
+	if value, ok := fileValues[key]; ok && value != "" {
+		return value, SOURCE_FILE
 	}
-	return getDefaultForKey(key)
+	return getDefaultForKey(key), SOURCE_DEFAULT
 }
 
 // getDefaultForKey is a handy method to get the default values if
@@ -63,6 +71,62 @@ func getDefaultForKey(key string) string {
 		return "5"
 	case consts.DATA_WEBSOCKET_DELAY:
 		return "30"
+	case consts.DISK_EXCLUDE_FSTYPES:
+		return "autofs,binfmt_misc,cgroup*,configfs,debugfs,devpts,devtmpfs,fusectl,hugetlbfs,mqueue,nsfs,overlay,proc,pstore,rpc_pipefs,securityfs,squashfs,sysfs,tmpfs,tracefs"
+	case consts.PROCESS_SNAPSHOT_ENABLED:
+		return "false"
+	case consts.PROCESS_SNAPSHOT_COUNT:
+		return "5"
+	case consts.AGENT_CONFIG_FILE:
+		return "agent-config.json"
+	case consts.BUFFER_FILE:
+		return "agent-buffer.json"
+	case consts.BUFFER_MAX_ENTRIES:
+		return "1000"
+	case consts.BUFFER_MAX_AGE:
+		return "1440"
+	case consts.HEALTH_BATCH_SIZE:
+		return "10"
+	case consts.HEALTH_BATCH_MAX_AGE:
+		return "60"
+	case consts.CLIENT_COMPRESSION:
+		return "gzip"
+	case consts.MAX_DECOMPRESSED_SIZE:
+		return "10485760"
+	case consts.CLIENT_MAX_RETRIES:
+		return "3"
+	case consts.CLIENT_RETRY_BASE_DELAY:
+		return "500"
+	case consts.CLIENT_RETRY_MAX_DELAY:
+		return "30000"
+	case consts.CLIENT_BREAKER_THRESHOLD:
+		return "5"
+	case consts.CLIENT_BREAKER_COOLDOWN:
+		return "60"
+	case consts.AGENT_GROUP:
+		return ""
+	case consts.REMOTE_CONFIG_INTERVAL:
+		return "300"
+	case consts.ENROLLMENT_TOKEN:
+		return ""
+	case consts.ADMIN_API_KEY:
+		return ""
+	case consts.CLIENT_CA:
+		return ""
+	case consts.CERT_RELOAD_INTERVAL:
+		return "60"
+	case consts.AGENT_CERT:
+		return ""
+	case consts.AGENT_KEY:
+		return ""
+	case consts.STATUS_ADDRESS:
+		return ""
+	case consts.SHUTDOWN_TIMEOUT:
+		return "30"
+	case consts.HOST_INVENTORY_INTERVAL:
+		return "3600"
+	case consts.CONFIG_FILE:
+		return ""
 	}
 	return ""
 }
@@ -30,6 +30,35 @@ func TestVariable_GetVariable_ReturnsValueFromEnvironmentVariable(t *testing.T)
 	os.Setenv(consts.MINUTES_TO_INCLUDE_HEALTH, "15")
 	os.Setenv(consts.DATA_WEBSOCKET_DELAY, "120")
 	os.Setenv(consts.DC_HEALTH_DELAY, "120")
+	os.Setenv(consts.DISK_INCLUDE_FSTYPES, "ext4,xfs")
+	os.Setenv(consts.DISK_EXCLUDE_FSTYPES, "tmpfs")
+	os.Setenv(consts.PROCESS_SNAPSHOT_ENABLED, "true")
+	os.Setenv(consts.PROCESS_SNAPSHOT_COUNT, "10")
+	os.Setenv(consts.AGENT_CONFIG_FILE, "/etc/shm/agent.json")
+	os.Setenv(consts.BUFFER_FILE, "/var/lib/shm/buffer.json")
+	os.Setenv(consts.BUFFER_MAX_ENTRIES, "50")
+	os.Setenv(consts.BUFFER_MAX_AGE, "60")
+	os.Setenv(consts.HEALTH_BATCH_SIZE, "1")
+	os.Setenv(consts.HEALTH_BATCH_MAX_AGE, "300")
+	os.Setenv(consts.CLIENT_COMPRESSION, "zstd")
+	os.Setenv(consts.MAX_DECOMPRESSED_SIZE, "1024")
+	os.Setenv(consts.CLIENT_MAX_RETRIES, "5")
+	os.Setenv(consts.CLIENT_RETRY_BASE_DELAY, "100")
+	os.Setenv(consts.CLIENT_RETRY_MAX_DELAY, "10000")
+	os.Setenv(consts.CLIENT_BREAKER_THRESHOLD, "0")
+	os.Setenv(consts.CLIENT_BREAKER_COOLDOWN, "300")
+	os.Setenv(consts.AGENT_GROUP, "web")
+	os.Setenv(consts.REMOTE_CONFIG_INTERVAL, "60")
+	os.Setenv(consts.ENROLLMENT_TOKEN, "token")
+	os.Setenv(consts.ADMIN_API_KEY, "key")
+	os.Setenv(consts.CLIENT_CA, "ca.crt")
+	os.Setenv(consts.CERT_RELOAD_INTERVAL, "3600")
+	os.Setenv(consts.AGENT_CERT, "agent.crt")
+	os.Setenv(consts.AGENT_KEY, "agent.key")
+	os.Setenv(consts.STATUS_ADDRESS, "127.0.0.1:9100")
+	os.Setenv(consts.SHUTDOWN_TIMEOUT, "10")
+	os.Setenv(consts.HOST_INVENTORY_INTERVAL, "600")
+	os.Setenv(consts.CONFIG_FILE, "/etc/shm/config.yaml")
 
 	assert.Equal(t, "4000", GetVariable(consts.API_PORT))
 	assert.Equal(t, "https://api.pr-developers.com/api/v1", GetVariable(consts.API_URL))
@@ -47,6 +76,35 @@ func TestVariable_GetVariable_ReturnsValueFromEnvironmentVariable(t *testing.T)
 	assert.Equal(t, "15", GetVariable(consts.MINUTES_TO_INCLUDE_HEALTH))
 	assert.Equal(t, "120", GetVariable(consts.DATA_WEBSOCKET_DELAY))
 	assert.Equal(t, "120", GetVariable(consts.DC_HEALTH_DELAY))
+	assert.Equal(t, "ext4,xfs", GetVariable(consts.DISK_INCLUDE_FSTYPES))
+	assert.Equal(t, "tmpfs", GetVariable(consts.DISK_EXCLUDE_FSTYPES))
+	assert.Equal(t, "true", GetVariable(consts.PROCESS_SNAPSHOT_ENABLED))
+	assert.Equal(t, "10", GetVariable(consts.PROCESS_SNAPSHOT_COUNT))
+	assert.Equal(t, "/etc/shm/agent.json", GetVariable(consts.AGENT_CONFIG_FILE))
+	assert.Equal(t, "/var/lib/shm/buffer.json", GetVariable(consts.BUFFER_FILE))
+	assert.Equal(t, "50", GetVariable(consts.BUFFER_MAX_ENTRIES))
+	assert.Equal(t, "60", GetVariable(consts.BUFFER_MAX_AGE))
+	assert.Equal(t, "1", GetVariable(consts.HEALTH_BATCH_SIZE))
+	assert.Equal(t, "300", GetVariable(consts.HEALTH_BATCH_MAX_AGE))
+	assert.Equal(t, "zstd", GetVariable(consts.CLIENT_COMPRESSION))
+	assert.Equal(t, "1024", GetVariable(consts.MAX_DECOMPRESSED_SIZE))
+	assert.Equal(t, "5", GetVariable(consts.CLIENT_MAX_RETRIES))
+	assert.Equal(t, "100", GetVariable(consts.CLIENT_RETRY_BASE_DELAY))
+	assert.Equal(t, "10000", GetVariable(consts.CLIENT_RETRY_MAX_DELAY))
+	assert.Equal(t, "0", GetVariable(consts.CLIENT_BREAKER_THRESHOLD))
+	assert.Equal(t, "300", GetVariable(consts.CLIENT_BREAKER_COOLDOWN))
+	assert.Equal(t, "web", GetVariable(consts.AGENT_GROUP))
+	assert.Equal(t, "60", GetVariable(consts.REMOTE_CONFIG_INTERVAL))
+	assert.Equal(t, "token", GetVariable(consts.ENROLLMENT_TOKEN))
+	assert.Equal(t, "key", GetVariable(consts.ADMIN_API_KEY))
+	assert.Equal(t, "ca.crt", GetVariable(consts.CLIENT_CA))
+	assert.Equal(t, "3600", GetVariable(consts.CERT_RELOAD_INTERVAL))
+	assert.Equal(t, "agent.crt", GetVariable(consts.AGENT_CERT))
+	assert.Equal(t, "agent.key", GetVariable(consts.AGENT_KEY))
+	assert.Equal(t, "127.0.0.1:9100", GetVariable(consts.STATUS_ADDRESS))
+	assert.Equal(t, "10", GetVariable(consts.SHUTDOWN_TIMEOUT))
+	assert.Equal(t, "600", GetVariable(consts.HOST_INVENTORY_INTERVAL))
+	assert.Equal(t, "/etc/shm/config.yaml", GetVariable(consts.CONFIG_FILE))
 
 	os.Clearenv()
 }
@@ -69,9 +127,44 @@ func TestVariable_GetVariable_ReturnsDefaultValues(t *testing.T) {
 	assert.Equal(t, "5", GetVariable(consts.MINUTES_TO_INCLUDE_HEALTH))
 	assert.Equal(t, "30", GetVariable(consts.DATA_WEBSOCKET_DELAY))
 	assert.Equal(t, "30", GetVariable(consts.DC_HEALTH_DELAY))
+	assert.Equal(t, "", GetVariable(consts.DISK_INCLUDE_FSTYPES))
+	assert.Contains(t, GetVariable(consts.DISK_EXCLUDE_FSTYPES), "tmpfs")
+	assert.Equal(t, "false", GetVariable(consts.PROCESS_SNAPSHOT_ENABLED))
+	assert.Equal(t, "5", GetVariable(consts.PROCESS_SNAPSHOT_COUNT))
+	assert.Equal(t, "agent-config.json", GetVariable(consts.AGENT_CONFIG_FILE))
+	assert.Equal(t, "agent-buffer.json", GetVariable(consts.BUFFER_FILE))
+	assert.Equal(t, "1000", GetVariable(consts.BUFFER_MAX_ENTRIES))
+	assert.Equal(t, "1440", GetVariable(consts.BUFFER_MAX_AGE))
+	assert.Equal(t, "10", GetVariable(consts.HEALTH_BATCH_SIZE))
+	assert.Equal(t, "60", GetVariable(consts.HEALTH_BATCH_MAX_AGE))
+	assert.Equal(t, "gzip", GetVariable(consts.CLIENT_COMPRESSION))
+	assert.Equal(t, "10485760", GetVariable(consts.MAX_DECOMPRESSED_SIZE))
+	assert.Equal(t, "3", GetVariable(consts.CLIENT_MAX_RETRIES))
+	assert.Equal(t, "500", GetVariable(consts.CLIENT_RETRY_BASE_DELAY))
+	assert.Equal(t, "30000", GetVariable(consts.CLIENT_RETRY_MAX_DELAY))
+	assert.Equal(t, "5", GetVariable(consts.CLIENT_BREAKER_THRESHOLD))
+	assert.Equal(t, "60", GetVariable(consts.CLIENT_BREAKER_COOLDOWN))
+	assert.Equal(t, "", GetVariable(consts.AGENT_GROUP))
+	assert.Equal(t, "300", GetVariable(consts.REMOTE_CONFIG_INTERVAL))
+	assert.Equal(t, "", GetVariable(consts.ENROLLMENT_TOKEN))
+	assert.Equal(t, "", GetVariable(consts.ADMIN_API_KEY))
+	assert.Equal(t, "", GetVariable(consts.CLIENT_CA))
+	assert.Equal(t, "60", GetVariable(consts.CERT_RELOAD_INTERVAL))
+	assert.Equal(t, "", GetVariable(consts.AGENT_CERT))
+	assert.Equal(t, "", GetVariable(consts.AGENT_KEY))
+	assert.Equal(t, "", GetVariable(consts.STATUS_ADDRESS))
+	assert.Equal(t, "30", GetVariable(consts.SHUTDOWN_TIMEOUT))
+	assert.Equal(t, "3600", GetVariable(consts.HOST_INVENTORY_INTERVAL))
+	assert.Equal(t, "", GetVariable(consts.CONFIG_FILE))
 	assert.Equal(t, "", GetVariable("test-value"))
 }
 
 func TestVariable_GetVariable_WithArgsReturnsFromArgs(t *testing.T) {
+	os.Clearenv()
+	os.Setenv(consts.API_PORT, "4000")
 
+	assert.Equal(t, "5000", GetVariableWithArgs(consts.API_PORT, "5000"))
+	assert.Equal(t, "4000", GetVariableWithArgs(consts.API_PORT, ""))
+
+	os.Clearenv()
 }
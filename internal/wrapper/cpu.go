@@ -0,0 +1,23 @@
+package wrapper
+
+import (
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// CPUInformation is an interface which provides method signatures for fetching cpu information
+type CPUInformation interface {
+	Times(perCPU bool) ([]cpu.TimesStat, error)
+}
+
+// GopsCPU is the implementation of gopsutil cpu
+type GopsCPU struct {
+}
+
+var (
+	_ CPUInformation = (*GopsCPU)(nil)
+)
+
+// Times returns the cumulative cpu times for the host, or for each core if perCPU is true
+func (c *GopsCPU) Times(perCPU bool) ([]cpu.TimesStat, error) {
+	return cpu.Times(perCPU)
+}
@@ -0,0 +1,35 @@
+package wrapper
+
+import (
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// DiskInformation is an interface which provides method signatures for fetching disk information
+type DiskInformation interface {
+	Partitions(all bool) ([]disk.PartitionStat, error)
+	Usage(path string) (*disk.UsageStat, error)
+	IOCounters() (map[string]disk.IOCountersStat, error)
+}
+
+// GopsDisk is the implementation of gopsutil disk
+type GopsDisk struct {
+}
+
+var (
+	_ DiskInformation = (*GopsDisk)(nil)
+)
+
+// Partitions returns the mounted partitions, including pseudo filesystems if all is true
+func (d *GopsDisk) Partitions(all bool) ([]disk.PartitionStat, error) {
+	return disk.Partitions(all)
+}
+
+// Usage returns the filesystem usage of the given mountpoint
+func (d *GopsDisk) Usage(path string) (*disk.UsageStat, error) {
+	return disk.Usage(path)
+}
+
+// IOCounters returns the cumulative io counters of each block device
+func (d *GopsDisk) IOCounters() (map[string]disk.IOCountersStat, error) {
+	return disk.IOCounters()
+}
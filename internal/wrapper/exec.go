@@ -0,0 +1,66 @@
+package wrapper
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"time"
+)
+
+const (
+	// killWaitDelay is how long to wait for a killed command to release its output before giving up on it
+	killWaitDelay = time.Second
+)
+
+// Executor is an interface which provides method signatures for running external commands
+type Executor interface {
+	Execute(ctx context.Context, name string, args ...string) ([]byte, int, error)
+}
+
+// DefaultExecutor is the wrapper for os/exec
+type DefaultExecutor struct {
+}
+
+var (
+	_ Executor = (*DefaultExecutor)(nil)
+)
+
+// Execute runs a command and returns its standard output and exit code. An error is only
+// returned if the command could not be started or was stopped by the context.
+// The command runs in its own process group so children it started are killed with it
+func (e *DefaultExecutor) Execute(ctx context.Context, name string, args ...string) ([]byte, int, error) {
+	var output bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = &output
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, -1, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		killProcessGroup(cmd)
+		// a child which left the process group can keep the output open, don't wait on it forever
+		select {
+		case <-done:
+		case <-time.After(killWaitDelay):
+		}
+		return nil, -1, ctx.Err()
+	}
+
+	if exitError, ok := err.(*exec.ExitError); ok {
+		return output.Bytes(), exitError.ExitCode(), nil
+	}
+	if err != nil {
+		return output.Bytes(), -1, err
+	}
+	return output.Bytes(), 0, nil
+}
@@ -0,0 +1,39 @@
+package wrapper
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExec_Execute_ReturnsOutputAndExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	executor := &DefaultExecutor{}
+
+	output, exitCode, err := executor.Execute(context.Background(), "sh", "-c", "echo WARNING; exit 1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, "WARNING\n", string(output))
+}
+
+func TestExec_Execute_KillsChildrenOnTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	executor := &DefaultExecutor{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, exitCode, err := executor.Execute(ctx, "sh", "-c", "sleep 10 & sleep 10")
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, -1, exitCode)
+	assert.Less(t, int64(time.Since(start)), int64(killWaitDelay))
+}
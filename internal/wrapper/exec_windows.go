@@ -0,0 +1,14 @@
+package wrapper
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op, windows has no process groups to signal
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+// killProcessGroup kills the command
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
@@ -0,0 +1,43 @@
+package wrapper
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"github.com/shirou/gopsutil/v3/load"
+)
+
+// LoadInformation is an interface which provides method signatures for fetching load information
+type LoadInformation interface {
+	Info() (*types.Load, error)
+}
+
+// GopsLoad is the implementation of gopsutil load
+type GopsLoad struct {
+}
+
+var (
+	_ LoadInformation = (*GopsLoad)(nil)
+)
+
+// Info returns load averages and process counts
+func (l *GopsLoad) Info() (*types.Load, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return nil, err
+	}
+
+	misc, err := load.Misc()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Load{
+		Load1:           avg.Load1,
+		Load5:           avg.Load5,
+		Load15:          avg.Load15,
+		ProcsTotal:      uint64(misc.ProcsTotal),
+		ProcsRunning:    uint64(misc.ProcsRunning),
+		ProcsBlocked:    uint64(misc.ProcsBlocked),
+		ContextSwitches: uint64(misc.Ctxt),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package wrapper
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// MemoryInformation is an interface which provides method signatures for fetching memory information
+type MemoryInformation interface {
+	Info() (*types.Memory, error)
+}
+
+// GopsMemory is the implementation of gopsutil mem
+type GopsMemory struct {
+}
+
+var (
+	_ MemoryInformation = (*GopsMemory)(nil)
+)
+
+// Info returns memory and swap information
+func (m *GopsMemory) Info() (*types.Memory, error) {
+	memory, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	swap, err := mem.SwapMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Memory{
+		Total:     memory.Total,
+		Used:      memory.Used,
+		Available: memory.Available,
+		Cached:    memory.Cached,
+		SwapTotal: swap.Total,
+		SwapUsed:  swap.Used,
+	}, nil
+}
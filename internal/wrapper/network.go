@@ -0,0 +1,23 @@
+package wrapper
+
+import (
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// NetworkInformation is an interface which provides method signatures for fetching network information
+type NetworkInformation interface {
+	IOCounters() ([]net.IOCountersStat, error)
+}
+
+// GopsNetwork is the implementation of gopsutil net
+type GopsNetwork struct {
+}
+
+var (
+	_ NetworkInformation = (*GopsNetwork)(nil)
+)
+
+// IOCounters returns the cumulative io counters of each network interface
+func (n *GopsNetwork) IOCounters() ([]net.IOCountersStat, error) {
+	return net.IOCounters(true)
+}
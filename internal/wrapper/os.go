@@ -13,6 +13,7 @@ type OperatingSystem interface {
 	IsNotExist(err error) bool
 	Stat(name string) (os.FileInfo, error)
 	Remove(name string) error
+	Rename(oldPath string, newPath string) error
 }
 
 // DefaultOS is the wrapper for the default OS
@@ -52,3 +53,8 @@ func (d *DefaultOS) Stat(name string) (os.FileInfo, error) {
 func (d *DefaultOS) Remove(name string) error {
 	return os.Remove(name)
 }
+
+// Rename is a wrapper for os.Rename
+func (d *DefaultOS) Rename(oldPath string, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
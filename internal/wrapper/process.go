@@ -0,0 +1,63 @@
+package wrapper
+
+import (
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessInformation is an interface which provides method signatures for fetching process information
+type ProcessInformation interface {
+	Processes() ([]types.Process, error)
+}
+
+// GopsProcess is the implementation of gopsutil process
+type GopsProcess struct {
+	// processes are kept between calls as gopsutil calculates cpu usage from the previous call
+	processes map[processKey]*process.Process
+}
+
+// processKey identifies a process, the create time prevents a reused PID from inheriting the cpu usage of the previous process
+type processKey struct {
+	pid        int32
+	createTime int64
+}
+
+var (
+	_ ProcessInformation = (*GopsProcess)(nil)
+)
+
+// Processes returns information about all running processes. Fields which can not be read
+// (ie, due to permissions) are left empty
+func (p *GopsProcess) Processes() ([]types.Process, error) {
+	running, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make(map[processKey]*process.Process, len(running))
+	data := make([]types.Process, 0, len(running))
+	for _, proc := range running {
+		key := processKey{pid: proc.Pid}
+		key.createTime, _ = proc.CreateTime()
+		if previous, ok := p.processes[key]; ok {
+			proc = previous
+		}
+		processes[key] = proc
+
+		info := types.Process{
+			PID: proc.Pid,
+		}
+		info.Name, _ = proc.Name()
+		info.Cmdline, _ = proc.Cmdline()
+		info.User, _ = proc.Username()
+		info.CPUPercent, _ = proc.Percent(0)
+		if memory, err := proc.MemoryInfo(); err == nil {
+			info.RSS = memory.RSS
+		}
+		data = append(data, info)
+	}
+	p.processes = processes
+
+	return data, nil
+}
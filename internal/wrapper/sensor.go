@@ -0,0 +1,75 @@
+package wrapper
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/PR-Developers/server-health-monitor/internal/types"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// SensorInformation is an interface which provides method signatures for fetching hardware sensor information
+type SensorInformation interface {
+	Temperatures() ([]types.Temperature, error)
+	Fans() ([]types.Fan, error)
+}
+
+// GopsSensor is the implementation of gopsutil sensors
+type GopsSensor struct {
+}
+
+var (
+	_ SensorInformation = (*GopsSensor)(nil)
+)
+
+// Temperatures returns all temperature sensor readings. Readings may be returned alongside
+// an error if only some of the sensors could not be read
+func (s *GopsSensor) Temperatures() ([]types.Temperature, error) {
+	sensors, err := host.SensorsTemperatures()
+	temperatures := make([]types.Temperature, 0, len(sensors))
+	for _, sensor := range sensors {
+		temperatures = append(temperatures, types.Temperature{
+			SensorKey: sensor.SensorKey,
+			Current:   sensor.Temperature,
+			High:      sensor.High,
+			Critical:  sensor.Critical,
+		})
+	}
+	return temperatures, err
+}
+
+// Fans returns all fan sensor readings. gopsutil does not support fans so these are
+// read from hwmon, meaning no fans will be returned on platforms other than linux
+func (s *GopsSensor) Fans() ([]types.Fan, error) {
+	files, err := filepath.Glob("/sys/class/hwmon/hwmon*/fan*_input")
+	if err != nil {
+		return nil, err
+	}
+
+	fans := make([]types.Fan, 0, len(files))
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		rpm, err := strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		directory := filepath.Dir(file)
+		key := strings.TrimSuffix(filepath.Base(file), "_input")
+		if name, err := os.ReadFile(filepath.Join(directory, "name")); err == nil {
+			key = strings.TrimSpace(string(name)) + "_" + key
+		}
+
+		fans = append(fans, types.Fan{
+			SensorKey: key,
+			RPM:       rpm,
+		})
+	}
+	return fans, nil
+}
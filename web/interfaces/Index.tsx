@@ -25,14 +25,46 @@ export interface Host {
   online?: boolean;
   lastConnected?: number;
   health?: Health[];
+  reboots?: Reboot[];
+}
+
+export interface Reboot {
+  agentID: string;
+  createTime: number;
+  bootTime: number;
+  previousUptime: number;
+  downtime: number;
 }
 
 export interface Health {
   id: string;
   agentID: string;
   createTime: number;
+  receivedTime?: number;
   updateTime: number;
   online?: boolean;
+  diskIO?: DiskIO[];
+  network?: NetworkIO[];
+}
+
+export interface DiskIO {
+  device: string;
+  readBytesPerSecond: number;
+  writeBytesPerSecond: number;
+  readsPerSecond: number;
+  writesPerSecond: number;
+}
+
+export interface NetworkIO {
+  interface: string;
+  rxBytesPerSecond: number;
+  txBytesPerSecond: number;
+  rxPacketsPerSecond: number;
+  txPacketsPerSecond: number;
+  rxErrorsPerSecond: number;
+  txErrorsPerSecond: number;
+  rxDropsPerSecond: number;
+  txDropsPerSecond: number;
 }
 
 export interface HealthResponse extends StandardResponse {